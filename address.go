@@ -0,0 +1,102 @@
+/*
+Copyright 2016 Arroyo Networks, LLC
+Copyright 2019 Project Contributors (See AUTHORS File).
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package splice
+
+import (
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Lifetime of an address which never expires.
+const AddressLifetimeForever = time.Duration(math.MaxInt64)
+
+// Flags describing the state of a configured address.
+// The values mirror the IFA_F_* flags used by Linux's rtnetlink.
+type AddressFlags uint32
+
+const (
+	AddressFlagSecondary      AddressFlags = 0x01  // Secondary (alias) address.
+	AddressFlagNoDAD          AddressFlags = 0x02  // Duplicate address detection is disabled.
+	AddressFlagOptimistic     AddressFlags = 0x04  // Optimistic DAD is in progress.
+	AddressFlagDADFailed      AddressFlags = 0x08  // Duplicate address detection failed.
+	AddressFlagHomeAddress    AddressFlags = 0x10  // Mobile IPv6 home address.
+	AddressFlagDeprecated     AddressFlags = 0x20  // The preferred lifetime has expired.
+	AddressFlagTentative      AddressFlags = 0x40  // Duplicate address detection is in progress.
+	AddressFlagPermanent      AddressFlags = 0x80  // Statically configured, never expires.
+	AddressFlagManageTempAddr AddressFlags = 0x100 // Temporary addresses are derived from this prefix.
+	AddressFlagNoPrefixRoute  AddressFlags = 0x200 // No prefix route was installed.
+	AddressFlagMCAutoJoin     AddressFlags = 0x400 // The multicast group is joined automatically.
+	AddressFlagStablePrivacy  AddressFlags = 0x800 // Stable privacy address (RFC 7217).
+)
+
+var addressFlagNames = []string{
+	"secondary", "nodad", "optimistic", "dadfailed", "homeaddress", "deprecated",
+	"tentative", "permanent", "mngtmpaddr", "noprefixroute", "autojoin", "stable-privacy",
+}
+
+func (f AddressFlags) String() string {
+	var names []string
+	for i, name := range addressFlagNames {
+		if f&(1<<uint(i)) != 0 {
+			names = append(names, name)
+		}
+	}
+	if rest := f &^ (1<<uint(len(addressFlagNames)) - 1); rest != 0 {
+		names = append(names, "0x"+strconv.FormatUint(uint64(rest), 16))
+	}
+	return strings.Join(names, "|")
+}
+
+// An IP address configured on an interface.
+type Address struct {
+	InterfaceIndex    int
+	IPNet             *net.IPNet
+	Flags             AddressFlags
+	PreferredLifetime time.Duration // AddressLifetimeForever if it never expires.
+	ValidLifetime     time.Duration // AddressLifetimeForever if it never expires.
+}
+
+// The kind of change reported by an AddressEvent.
+type AddressEventType int
+
+const (
+	AddressEventAdd    AddressEventType = iota + 1 // An address was added or updated.
+	AddressEventDelete                             // An address was removed.
+)
+
+var addressEventTypeNames = map[AddressEventType]string{
+	AddressEventAdd:    "add",
+	AddressEventDelete: "delete",
+}
+
+func (t AddressEventType) String() string {
+	if name, ok := addressEventTypeNames[t]; ok {
+		return name
+	}
+	return strconv.Itoa(int(t))
+}
+
+// A change to the addresses configured on the system, delivered by
+// AddressSubscribe.
+type AddressEvent struct {
+	Type    AddressEventType
+	Address *Address
+}
@@ -18,12 +18,34 @@ limitations under the License.
 package splice
 
 import (
+	"context"
 	"github.com/vishvananda/netlink"
+	"math"
 	"net"
+	"time"
 )
 
 // Provides ip address manipulation for Linux using netlink.
 
+// Converts an address lifetime in seconds, as reported by netlink.
+func addressLifetime(seconds int) time.Duration {
+	if uint32(seconds) == math.MaxUint32 {
+		return AddressLifetimeForever
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// Converts a netlink address into a splice Address.
+func addressFromNetlink(addr *netlink.Addr) *Address {
+	return &Address{
+		InterfaceIndex:    addr.LinkIndex,
+		IPNet:             addr.IPNet,
+		Flags:             AddressFlags(addr.Flags),
+		PreferredLifetime: addressLifetime(addr.PreferedLft),
+		ValidLifetime:     addressLifetime(addr.ValidLft),
+	}
+}
+
 // Returns a list of IP addresses configured on the given interface.
 // This is equivalent to 'ip address show <interface>'
 func AddressList(intf *net.Interface) ([]*net.IPNet, error) {
@@ -46,6 +68,29 @@ func AddressList(intf *net.Interface) ([]*net.IPNet, error) {
 	return ipAddresses, err
 }
 
+// Returns the IP addresses configured on the given interface along with
+// their flags and lifetimes, decoded the same way as by AddressSubscribe.
+// This is equivalent to 'ip address show <interface>'
+func AddressListDetailed(intf *net.Interface) ([]*Address, error) {
+
+	link, err := netlink.LinkByIndex(intf.Index)
+	if err != nil {
+		return nil, err
+	}
+
+	addrs, err := netlink.AddrList(link, netlink.FAMILY_ALL)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*Address, 0, len(addrs))
+	for i := range addrs {
+		result = append(result, addressFromNetlink(&addrs[i]))
+	}
+
+	return result, nil
+}
+
 // Adds an IP address to an interface.
 // This is equivalent to 'ip address add <address> dev <intf.Name>'
 func AddressAdd(intf *net.Interface, address *net.IPNet) error {
@@ -81,3 +126,53 @@ func AddressDelete(intf *net.Interface, address *net.IPNet) error {
 
 	return err
 }
+
+// Converts a netlink address update into a splice AddressEvent.
+func addressEventFromNetlink(update *netlink.AddrUpdate) AddressEvent {
+
+	ipNet := update.LinkAddress
+
+	event := AddressEvent{
+		Type: AddressEventAdd,
+		Address: addressFromNetlink(&netlink.Addr{
+			IPNet:       &ipNet,
+			LinkIndex:   update.LinkIndex,
+			Flags:       update.Flags,
+			Scope:       update.Scope,
+			PreferedLft: update.PreferedLft,
+			ValidLft:    update.ValidLft,
+		}),
+	}
+
+	if !update.NewAddr {
+		event.Type = AddressEventDelete
+	}
+
+	return event
+}
+
+// Subscribes to changes of the addresses configured on any interface. An add
+// event is also reported when the flags or lifetimes of an existing address
+// change, for example once duplicate address detection completes. Events are
+// delivered on the returned channel until the context is cancelled, after
+// which the channel is closed. The channel is also closed if the
+// subscription fails.
+// This is equivalent to 'ip monitor address'.
+func AddressSubscribe(ctx context.Context) (<-chan AddressEvent, error) {
+
+	updates := make(chan netlink.AddrUpdate)
+	done := make(chan struct{})
+
+	if err := netlink.AddrSubscribeWithOptions(updates, done, netlink.AddrSubscribeOptions{}); err != nil {
+		return nil, err
+	}
+
+	events := make(chan AddressEvent)
+
+	go subscriptionForward(ctx, updates, done, events, func(u interface{}) (interface{}, bool) {
+		update := u.(netlink.AddrUpdate)
+		return addressEventFromNetlink(&update), true
+	})
+
+	return events, nil
+}
@@ -18,9 +18,11 @@ limitations under the License.
 package splice_test
 
 import (
+	"context"
 	"github.com/arroyonetworks/splice"
 	"net"
 	"testing"
+	"time"
 )
 
 // ============================================================================
@@ -75,6 +77,48 @@ func TestAddressList_InvalidIntfValue(t *testing.T) {
 	}
 }
 
+// ============================================================================
+//	AddressListDetailed
+// ============================================================================
+
+func TestAddressListDetailed(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Get the IP Addresses
+	//			Expect: No error
+	// ------------------------------------------------------------------------
+
+	addrs, err := splice.AddressListDetailed(config.loopbackIntf)
+	if err != nil {
+		t.Fatal("AddressListDetailed Returned Error: ", err)
+	}
+
+	// (2)	Search the Returned IP Addresses
+	//			Expect: The loopback address is returned as a permanent address
+	// ------------------------------------------------------------------------
+
+	var found *splice.Address
+	for _, addr := range addrs {
+		if addr.IPNet.String() == IPv4LoopbackAddr.String() {
+			found = addr
+		}
+	}
+	if found == nil {
+		t.Fatal("Loopback Address Not Returned")
+	}
+	if found.InterfaceIndex != config.loopbackIntf.Index {
+		t.Fatal("Loopback Address Has Unexpected Interface Index: ", found.InterfaceIndex)
+	}
+	if found.Flags&splice.AddressFlagPermanent == 0 {
+		t.Fatal("Loopback Address Is Not Flagged Permanent: ", found.Flags)
+	}
+	if found.ValidLifetime != splice.AddressLifetimeForever {
+		t.Fatal("Loopback Address Has Unexpected Valid Lifetime: ", found.ValidLifetime)
+	}
+}
+
 // ============================================================================
 //	AddressAdd
 // ============================================================================
@@ -162,3 +206,95 @@ func TestAddressDelete_InvalidAddressValue(t *testing.T) {
 		t.Fatal("AddressDelete Did Not Return an Error With Invalid Address")
 	}
 }
+
+// ============================================================================
+//	AddressSubscribe
+// ============================================================================
+
+// Waits for an event of the given type for the given address, ignoring any
+// unrelated events received in the meantime.
+func waitAddressEvent(t *testing.T, events <-chan splice.AddressEvent, eventType splice.AddressEventType, address *net.IPNet) *splice.AddressEvent {
+
+	timeout := time.After(5 * time.Second)
+
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				t.Fatal("Address Event Channel Closed Unexpectedly")
+			}
+			if event.Type == eventType && event.Address.IPNet.String() == address.String() {
+				return &event
+			}
+		case <-timeout:
+			t.Fatalf("Timed Out Waiting for Address Event (%s %s)", eventType, address)
+		}
+	}
+}
+
+func TestAddressSubscribe(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// (1)	Subscribe to Address Events
+	//			Expect: No error
+	// ------------------------------------------------------------------------
+
+	events, err := splice.AddressSubscribe(ctx)
+	if err != nil {
+		t.Fatal("AddressSubscribe Returned Error: ", err)
+	}
+
+	// (2)	Add a Loopback Address
+	//			Expect: A permanent add event on the loopback interface
+	// ------------------------------------------------------------------------
+
+	newAddr := RandomIPv4()
+
+	if err := splice.AddressAdd(config.loopbackIntf, newAddr); err != nil {
+		t.Fatal("AddressAdd Returned Error: ", err)
+	}
+
+	event := waitAddressEvent(t, events, splice.AddressEventAdd, newAddr)
+	if event.Address.InterfaceIndex != config.loopbackIntf.Index {
+		t.Fatal("Add Event Has Unexpected Interface Index: ", event.Address.InterfaceIndex)
+	}
+	if event.Address.Flags&splice.AddressFlagPermanent == 0 {
+		t.Fatal("Add Event Is Not Flagged Permanent: ", event.Address.Flags)
+	}
+	if event.Address.ValidLifetime != splice.AddressLifetimeForever {
+		t.Fatal("Add Event Has Unexpected Valid Lifetime: ", event.Address.ValidLifetime)
+	}
+
+	// (3)	Delete the Address
+	//			Expect: A delete event for the address
+	// ------------------------------------------------------------------------
+
+	if err := splice.AddressDelete(config.loopbackIntf, newAddr); err != nil {
+		t.Fatal("AddressDelete Returned Error: ", err)
+	}
+
+	waitAddressEvent(t, events, splice.AddressEventDelete, newAddr)
+
+	// (4)	Cancel the Context
+	//			Expect: The event channel is closed
+	// ------------------------------------------------------------------------
+
+	cancel()
+
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case _, ok := <-events:
+			if !ok {
+				return
+			}
+		case <-timeout:
+			t.Fatal("Address Event Channel Was Not Closed After Cancel")
+		}
+	}
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2016 Arroyo Networks, LLC
+Copyright 2019 Project Contributors (See AUTHORS File).
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package splice
+
+import (
+	"strconv"
+)
+
+// The protocol of the packets carried by a bareudp interface, given as its
+// ethertype.
+type BareUDPProtocol int
+
+const (
+	BareUDPProtocolIPv4          BareUDPProtocol = 0x0800
+	BareUDPProtocolIPv6          BareUDPProtocol = 0x86dd
+	BareUDPProtocolMPLSUnicast   BareUDPProtocol = 0x8847
+	BareUDPProtocolMPLSMulticast BareUDPProtocol = 0x8848
+)
+
+func (p BareUDPProtocol) String() string {
+	switch p {
+	case BareUDPProtocolIPv4:
+		return "ipv4"
+	case BareUDPProtocolIPv6:
+		return "ipv6"
+	case BareUDPProtocolMPLSUnicast:
+		return "mpls_uc"
+	case BareUDPProtocolMPLSMulticast:
+		return "mpls_mc"
+	}
+	return "0x" + strconv.FormatInt(int64(p), 16)
+}
+
+// Optional parameters used when creating a bareudp interface.
+// Fields left at their zero value use the system defaults.
+type BareUDPOptions struct {
+	SourcePortMin int // Lowest UDP source port used for encapsulated packets.
+
+	// Also carry IPv6 packets on an IPv4 interface, or MPLS multicast
+	// packets on an MPLS unicast interface.
+	MultiProto bool
+}
+
+// Describes the configuration of a bareudp interface.
+type BareUDPInfo struct {
+	Port          int
+	Protocol      BareUDPProtocol
+	SourcePortMin int
+	MultiProto    bool
+}
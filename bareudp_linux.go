@@ -0,0 +1,72 @@
+/*
+Copyright 2016 Arroyo Networks, LLC
+Copyright 2019 Project Contributors (See AUTHORS File).
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package splice
+
+import (
+	"errors"
+	"github.com/vishvananda/netlink"
+	"net"
+)
+
+// Provides bareudp interface management for Linux using netlink.
+
+// Returns the bareudp configuration of a netlink bareudp link.
+func bareUDPFromNetlink(bareudp *netlink.BareUDP) *BareUDPInfo {
+	return &BareUDPInfo{
+		Port:          int(bareudp.Port),
+		Protocol:      BareUDPProtocol(bareudp.EtherType),
+		SourcePortMin: int(bareudp.SrcPortMin),
+		MultiProto:    bareudp.MultiProto,
+	}
+}
+
+// Creates a bareudp interface, which encapsulates packets of the given
+// protocol directly in UDP and receives them on the given destination port.
+// The remote endpoint is taken from the metadata of each packet, set by
+// routes or eBPF programs. The options may be nil. The interface is created
+// administratively down.
+// This is equivalent to 'ip link add <name> type bareudp dstport <port>
+// ethertype <protocol>'.
+func LinkCreateBareUDP(name string, port int, protocol BareUDPProtocol, opts *BareUDPOptions) (*net.Interface, error) {
+
+	if port <= 0 || port > 0xffff {
+		return nil, errors.New("Destination port must be between 1 and 65535")
+	}
+	if protocol <= 0 || protocol > 0xffff {
+		return nil, errors.New("Invalid bareudp protocol")
+	}
+
+	if opts == nil {
+		opts = &BareUDPOptions{}
+	}
+
+	if opts.MultiProto && protocol != BareUDPProtocolIPv4 && protocol != BareUDPProtocolMPLSUnicast {
+		return nil, errors.New("Multiple protocols are only supported for IPv4 and MPLS unicast")
+	}
+
+	attrs := netlink.NewLinkAttrs()
+	attrs.Name = name
+
+	return linkAdd(&netlink.BareUDP{
+		LinkAttrs:  attrs,
+		Port:       uint16(port),
+		EtherType:  uint16(protocol),
+		SrcPortMin: uint16(opts.SourcePortMin),
+		MultiProto: opts.MultiProto,
+	})
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2016 Arroyo Networks, LLC
+Copyright 2019 Project Contributors (See AUTHORS File).
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package splice_test
+
+import (
+	"github.com/arroyonetworks/splice"
+	"testing"
+)
+
+// ============================================================================
+//	LinkCreateBareUDP
+// ============================================================================
+
+func TestLinkCreateBareUDP(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Create a bareudp Interface for MPLS
+	//			Expect: No error
+	// ------------------------------------------------------------------------
+
+	opts := &splice.BareUDPOptions{MultiProto: true}
+
+	intf, err := splice.LinkCreateBareUDP(RandomIntfName("bareudp"), 6635, splice.BareUDPProtocolMPLSUnicast, opts)
+	SkipIfNotSupported(t, err)
+	if err != nil {
+		t.Fatal("LinkCreateBareUDP Returned Error: ", err)
+	}
+
+	// (2)	Get the Link
+	//			Expect: The bareudp configuration is reported
+	// ------------------------------------------------------------------------
+
+	link, err := splice.LinkGet(intf)
+	if err != nil {
+		t.Fatal("LinkGet Returned Error: ", err)
+	}
+
+	if link.BareUDP == nil {
+		t.Fatal("Link Is Not a bareudp Interface: ", link.Kind)
+	}
+	if link.BareUDP.Port != 6635 || link.BareUDP.Protocol != splice.BareUDPProtocolMPLSUnicast {
+		t.Fatal("bareudp Has Unexpected Port or Protocol: ", link.BareUDP.Port, link.BareUDP.Protocol)
+	}
+	if !link.BareUDP.MultiProto {
+		t.Fatal("bareudp Is Not in Multiprotocol Mode")
+	}
+}
+
+func TestLinkCreateBareUDP_InvalidOptions(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Create a bareudp Interface Without a Port
+	//			Expect: Error
+	// ------------------------------------------------------------------------
+
+	if _, err := splice.LinkCreateBareUDP(RandomIntfName("bareudp"), 0, splice.BareUDPProtocolIPv4, nil); err == nil {
+		t.Fatal("LinkCreateBareUDP Did Not Return an Error Without a Port")
+	}
+
+	// (2)	Create a Multiprotocol bareudp Interface for IPv6
+	//			Expect: Error
+	// ------------------------------------------------------------------------
+
+	opts := &splice.BareUDPOptions{MultiProto: true}
+
+	if _, err := splice.LinkCreateBareUDP(RandomIntfName("bareudp"), 6635, splice.BareUDPProtocolIPv6, opts); err == nil {
+		t.Fatal("LinkCreateBareUDP Did Not Return an Error with Multiprotocol IPv6")
+	}
+}
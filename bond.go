@@ -0,0 +1,156 @@
+/*
+Copyright 2016 Arroyo Networks, LLC
+Copyright 2019 Project Contributors (See AUTHORS File).
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package splice
+
+import (
+	"net"
+	"strconv"
+)
+
+// The mode of a bond interface, selecting how traffic is distributed across
+// its members.
+type BondMode int
+
+const (
+	BondModeBalanceRR    BondMode = iota + 1 // Round-robin across members.
+	BondModeActiveBackup                     // A single active member, others on standby.
+	BondModeBalanceXOR                       // Members selected by the transmit hash policy.
+	BondModeBroadcast                        // Transmit on every member.
+	BondMode8023AD                           // IEEE 802.3ad dynamic link aggregation (LACP).
+	BondModeBalanceTLB                       // Adaptive transmit load balancing.
+	BondModeBalanceALB                       // Adaptive transmit and receive load balancing.
+)
+
+var bondModeNames = map[BondMode]string{
+	BondModeBalanceRR:    "balance-rr",
+	BondModeActiveBackup: "active-backup",
+	BondModeBalanceXOR:   "balance-xor",
+	BondModeBroadcast:    "broadcast",
+	BondMode8023AD:       "802.3ad",
+	BondModeBalanceTLB:   "balance-tlb",
+	BondModeBalanceALB:   "balance-alb",
+}
+
+func (m BondMode) String() string {
+	if name, ok := bondModeNames[m]; ok {
+		return name
+	}
+	return strconv.Itoa(int(m))
+}
+
+// The rate at which LACPDUs are requested from the link partner in
+// BondMode8023AD.
+type BondLACPRate int
+
+const (
+	BondLACPRateSlow BondLACPRate = iota + 1 // Every 30 seconds.
+	BondLACPRateFast                         // Every second.
+)
+
+var bondLACPRateNames = map[BondLACPRate]string{
+	BondLACPRateSlow: "slow",
+	BondLACPRateFast: "fast",
+}
+
+func (r BondLACPRate) String() string {
+	if name, ok := bondLACPRateNames[r]; ok {
+		return name
+	}
+	return strconv.Itoa(int(r))
+}
+
+// The policy used to select a member for transmission in BondModeBalanceXOR
+// and BondMode8023AD.
+type BondXmitHashPolicy int
+
+const (
+	BondXmitHashLayer2  BondXmitHashPolicy = iota + 1 // MAC addresses.
+	BondXmitHashLayer34                               // IP addresses and ports.
+	BondXmitHashLayer23                               // MAC and IP addresses.
+	BondXmitHashEncap23                               // MAC and IP addresses, of inner headers.
+	BondXmitHashEncap34                               // IP addresses and ports, of inner headers.
+)
+
+var bondXmitHashPolicyNames = map[BondXmitHashPolicy]string{
+	BondXmitHashLayer2:  "layer2",
+	BondXmitHashLayer34: "layer3+4",
+	BondXmitHashLayer23: "layer2+3",
+	BondXmitHashEncap23: "encap2+3",
+	BondXmitHashEncap34: "encap3+4",
+}
+
+func (p BondXmitHashPolicy) String() string {
+	if name, ok := bondXmitHashPolicyNames[p]; ok {
+		return name
+	}
+	return strconv.Itoa(int(p))
+}
+
+// Optional parameters used when creating a bond interface.
+// Fields left at their zero value use the system defaults.
+type BondOptions struct {
+	Mode           BondMode
+	MIIMon         int                // Link monitoring interval, in milliseconds.
+	LACPRate       BondLACPRate       // Only used with BondMode8023AD.
+	XmitHashPolicy BondXmitHashPolicy // Only used with BondModeBalanceXOR and BondMode8023AD.
+	Primary        *net.Interface     // Preferred active member in BondModeActiveBackup.
+}
+
+// Describes the configuration of a bond interface.
+type BondInfo struct {
+	Mode             BondMode
+	MIIMon           int
+	LACPRate         BondLACPRate
+	XmitHashPolicy   BondXmitHashPolicy
+	PrimaryIndex     int // 0 if no primary member is set.
+	ActiveSlaveIndex int // 0 if there is no active member.
+}
+
+// The role of a bond member.
+type BondMemberState int
+
+const (
+	BondMemberActive BondMemberState = iota + 1 // The member may carry traffic.
+	BondMemberBackup                            // The member is on standby.
+)
+
+func (s BondMemberState) String() string {
+	switch s {
+	case BondMemberActive:
+		return "active"
+	case BondMemberBackup:
+		return "backup"
+	}
+	return strconv.Itoa(int(s))
+}
+
+// A member interface of a bond and its link state.
+type BondMember struct {
+	Interface        *net.Interface
+	State            BondMemberState
+	LinkUp           bool // The link is up according to link monitoring.
+	LinkFailureCount int
+	PermHardwareAddr net.HardwareAddr // Hardware address before it was enslaved.
+}
+
+// The status of a bond interface and its members.
+type BondStatus struct {
+	Mode        BondMode
+	ActiveSlave *net.Interface // nil if there is no active member.
+	Members     []*BondMember
+}
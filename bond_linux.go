@@ -0,0 +1,178 @@
+/*
+Copyright 2016 Arroyo Networks, LLC
+Copyright 2019 Project Contributors (See AUTHORS File).
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package splice
+
+import (
+	"errors"
+	"fmt"
+	"github.com/vishvananda/netlink"
+	"net"
+)
+
+// Provides bond interface management for Linux using netlink.
+
+// Returns the bond configuration of a netlink bond link.
+func bondFromNetlink(bond *netlink.Bond) *BondInfo {
+
+	info := &BondInfo{
+		MIIMon:           bond.Miimon,
+		PrimaryIndex:     bond.Primary,
+		ActiveSlaveIndex: bond.ActiveSlave,
+	}
+
+	if bond.Mode >= 0 && bond.Mode < netlink.BOND_MODE_UNKNOWN {
+		info.Mode = BondMode(bond.Mode + 1)
+	}
+	if bond.LacpRate >= 0 && bond.LacpRate < netlink.BOND_LACP_RATE_UNKNOWN {
+		info.LACPRate = BondLACPRate(bond.LacpRate + 1)
+	}
+	if bond.XmitHashPolicy >= 0 && bond.XmitHashPolicy < netlink.BOND_XMIT_HASH_POLICY_UNKNOWN {
+		info.XmitHashPolicy = BondXmitHashPolicy(bond.XmitHashPolicy + 1)
+	}
+
+	return info
+}
+
+// Creates a bond interface. The interface is created administratively down.
+// This is equivalent to 'ip link add <name> type bond mode <mode>'.
+func LinkCreateBond(name string, opts *BondOptions) (*net.Interface, error) {
+
+	if opts == nil {
+		opts = &BondOptions{}
+	}
+
+	attrs := netlink.NewLinkAttrs()
+	attrs.Name = name
+
+	bond := netlink.NewLinkBond(attrs)
+
+	if opts.Mode != 0 {
+		if _, ok := bondModeNames[opts.Mode]; !ok {
+			return nil, errors.New("Unsupported bond mode")
+		}
+		bond.Mode = netlink.BondMode(opts.Mode - 1)
+	}
+	if opts.MIIMon != 0 {
+		bond.Miimon = opts.MIIMon
+	}
+	if opts.LACPRate != 0 {
+		if _, ok := bondLACPRateNames[opts.LACPRate]; !ok {
+			return nil, errors.New("Unsupported LACP rate")
+		}
+		bond.LacpRate = netlink.BondLacpRate(opts.LACPRate - 1)
+	}
+	if opts.XmitHashPolicy != 0 {
+		if _, ok := bondXmitHashPolicyNames[opts.XmitHashPolicy]; !ok {
+			return nil, errors.New("Unsupported transmit hash policy")
+		}
+		bond.XmitHashPolicy = netlink.BondXmitHashPolicy(opts.XmitHashPolicy - 1)
+	}
+	if opts.Primary != nil {
+		bond.Primary = opts.Primary.Index
+	}
+
+	return linkAdd(bond)
+}
+
+// Adds the member interface to the bond. The member is brought down before
+// being enslaved, as required by the kernel, and is brought back up by the
+// bond once enslaved.
+// This is equivalent to 'ip link set <member.Name> master <bond.Name>'.
+func BondEnslave(bond *net.Interface, member *net.Interface) error {
+
+	link, err := netlink.LinkByIndex(member.Index)
+	if err != nil {
+		return err
+	}
+
+	if err := netlink.LinkSetDown(link); err != nil {
+		return err
+	}
+
+	return netlink.LinkSetMasterByIndex(link, bond.Index)
+}
+
+// Removes the member interface from its bond.
+// This is equivalent to 'ip link set <member.Name> nomaster'.
+func BondRelease(member *net.Interface) error {
+
+	link, err := netlink.LinkByIndex(member.Index)
+	if err != nil {
+		return err
+	}
+
+	return netlink.LinkSetNoMaster(link)
+}
+
+// Returns the status of the given bond, including its active member and the
+// link state of every member.
+// This is equivalent to 'cat /proc/net/bonding/<bond.Name>'.
+func BondStatusGet(bond *net.Interface) (*BondStatus, error) {
+
+	link, err := netlink.LinkByIndex(bond.Index)
+	if err != nil {
+		return nil, err
+	}
+
+	nlBond, ok := link.(*netlink.Bond)
+	if !ok {
+		return nil, fmt.Errorf("link %q is not a bond", bond.Name)
+	}
+
+	info := bondFromNetlink(nlBond)
+	status := &BondStatus{Mode: info.Mode}
+
+	links, err := netlink.LinkList()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, member := range links {
+
+		attrs := member.Attrs()
+		if attrs.MasterIndex != bond.Index {
+			continue
+		}
+
+		slave, ok := attrs.Slave.(*netlink.BondSlave)
+		if !ok {
+			continue
+		}
+
+		intf := interfaceFromNetlink(attrs)
+
+		state := BondMemberBackup
+		if slave.State == netlink.BondStateActive {
+			state = BondMemberActive
+		}
+
+		status.Members = append(status.Members, &BondMember{
+			Interface:        intf,
+			State:            state,
+			LinkUp:           slave.MiiStatus == netlink.BondLinkUp,
+			LinkFailureCount: int(slave.LinkFailureCount),
+			PermHardwareAddr: slave.PermHardwareAddr,
+		})
+
+		if attrs.Index == info.ActiveSlaveIndex {
+			status.ActiveSlave = intf
+		}
+	}
+
+	return status, nil
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2016 Arroyo Networks, LLC
+Copyright 2019 Project Contributors (See AUTHORS File).
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package splice_test
+
+import (
+	"github.com/arroyonetworks/splice"
+	"net"
+	"testing"
+)
+
+// ============================================================================
+//	LinkCreateBond
+// ============================================================================
+
+func TestLinkCreateBond(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Create an 802.3ad Bond
+	//			Expect: No error
+	// ------------------------------------------------------------------------
+
+	opts := &splice.BondOptions{
+		Mode:           splice.BondMode8023AD,
+		MIIMon:         100,
+		LACPRate:       splice.BondLACPRateFast,
+		XmitHashPolicy: splice.BondXmitHashLayer34,
+	}
+
+	intf, err := splice.LinkCreateBond(RandomIntfName("bond"), opts)
+	SkipIfNotSupported(t, err)
+	if err != nil {
+		t.Fatal("LinkCreateBond Returned Error: ", err)
+	}
+
+	// (2)	Get the Link
+	//			Expect: The bond configuration is reported
+	// ------------------------------------------------------------------------
+
+	link, err := splice.LinkGet(intf)
+	if err != nil {
+		t.Fatal("LinkGet Returned Error: ", err)
+	}
+
+	info := link.Bond
+	if info == nil {
+		t.Fatal("Link Is Not a Bond: ", link.Kind)
+	}
+	if info.Mode != opts.Mode || info.MIIMon != opts.MIIMon {
+		t.Fatal("Bond Has Unexpected Mode or MII Monitoring: ", info.Mode, info.MIIMon)
+	}
+	if info.LACPRate != opts.LACPRate || info.XmitHashPolicy != opts.XmitHashPolicy {
+		t.Fatal("Bond Has Unexpected LACP Rate or Hash Policy: ", info.LACPRate, info.XmitHashPolicy)
+	}
+}
+
+func TestLinkCreateBond_InvalidMode(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Create a Bond with an Unknown Mode
+	//			Expect: Error
+	// ------------------------------------------------------------------------
+
+	opts := &splice.BondOptions{Mode: 42}
+
+	if _, err := splice.LinkCreateBond(RandomIntfName("bond"), opts); err == nil {
+		t.Fatal("LinkCreateBond Did Not Return an Error with Invalid Mode")
+	}
+}
+
+// ============================================================================
+//	BondEnslave / BondStatusGet / BondRelease
+// ============================================================================
+
+func TestBondEnslave(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Create an Active-Backup Bond with a Primary Member
+	// ------------------------------------------------------------------------
+
+	primary := GetDummyUpIntf(t)
+	backup := GetDummyUpIntf(t)
+
+	opts := &splice.BondOptions{
+		Mode:    splice.BondModeActiveBackup,
+		MIIMon:  100,
+		Primary: primary,
+	}
+
+	bond, err := splice.LinkCreateBond(RandomIntfName("bond"), opts)
+	SkipIfNotSupported(t, err)
+	if err != nil {
+		t.Fatal("LinkCreateBond Returned Error: ", err)
+	}
+
+	if err := splice.LinkBringUp(bond); err != nil {
+		t.Fatal("LinkBringUp Returned Error: ", err)
+	}
+
+	// (2)	Enslave Both Members
+	//			Expect: No error
+	// ------------------------------------------------------------------------
+
+	for _, member := range []*net.Interface{primary, backup} {
+		if err := splice.BondEnslave(bond, member); err != nil {
+			t.Fatal("BondEnslave Returned Error: ", err)
+		}
+	}
+
+	// (3)	Get the Bond Status
+	//			Expect: Both members, with the primary active
+	// ------------------------------------------------------------------------
+
+	status, err := splice.BondStatusGet(bond)
+	if err != nil {
+		t.Fatal("BondStatusGet Returned Error: ", err)
+	}
+
+	if status.Mode != splice.BondModeActiveBackup || len(status.Members) != 2 {
+		t.Fatal("Bond Status Has Unexpected Mode or Members: ", status.Mode, len(status.Members))
+	}
+	if status.ActiveSlave == nil || status.ActiveSlave.Index != primary.Index {
+		t.Fatal("Primary Member Is Not the Active Slave: ", status.ActiveSlave)
+	}
+
+	for _, member := range status.Members {
+		expected := splice.BondMemberBackup
+		if member.Interface.Index == primary.Index {
+			expected = splice.BondMemberActive
+		}
+		if member.State != expected {
+			t.Fatalf("Member %s Has Unexpected State: %s", member.Interface.Name, member.State)
+		}
+	}
+
+	// (4)	Release the Backup Member
+	//			Expect: Only the primary member remains
+	// ------------------------------------------------------------------------
+
+	if err := splice.BondRelease(backup); err != nil {
+		t.Fatal("BondRelease Returned Error: ", err)
+	}
+
+	status, err = splice.BondStatusGet(bond)
+	if err != nil {
+		t.Fatal("BondStatusGet Returned Error: ", err)
+	}
+	if len(status.Members) != 1 || status.Members[0].Interface.Index != primary.Index {
+		t.Fatal("Released Member Is Still Enslaved")
+	}
+}
+
+func TestBondStatusGet_NotABond(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Get the Bond Status of the Loopback Interface
+	//			Expect: Error
+	// ------------------------------------------------------------------------
+
+	if _, err := splice.BondStatusGet(config.loopbackIntf); err == nil {
+		t.Fatal("BondStatusGet Did Not Return an Error for a Non-Bond Link")
+	}
+}
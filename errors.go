@@ -0,0 +1,64 @@
+/*
+Copyright 2016 Arroyo Networks, LLC
+Copyright 2019 Project Contributors (See AUTHORS File).
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package splice
+
+import (
+	"errors"
+	"net"
+)
+
+var (
+	// Returned when the requested object could not be found.
+	ErrNotFound = errors.New("not found")
+
+	// Returned when the object to be added already exists.
+	ErrExists = errors.New("already exists")
+
+	// Returned when duplicate address detection failed for an address.
+	ErrDuplicateAddress = errors.New("duplicate address detected")
+)
+
+// Returned when a route to the given destination could not be found in the
+// routing table.
+type RouteNotFoundError struct {
+	Destination *net.IPNet
+}
+
+func (e *RouteNotFoundError) Error() string {
+	return "route to " + e.Destination.String() + " not found"
+}
+
+// Allows errors.Is(err, ErrNotFound) to match a RouteNotFoundError.
+func (e *RouteNotFoundError) Is(target error) bool {
+	return target == ErrNotFound
+}
+
+// Returned when a route to the given destination is already present in the
+// routing table.
+type RouteExistsError struct {
+	Destination *net.IPNet
+}
+
+func (e *RouteExistsError) Error() string {
+	return "route to " + e.Destination.String() + " already exists"
+}
+
+// Allows errors.Is(err, ErrExists) to match a RouteExistsError.
+func (e *RouteExistsError) Is(target error) bool {
+	return target == ErrExists
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2016 Arroyo Networks, LLC
+Copyright 2019 Project Contributors (See AUTHORS File).
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package splice
+
+import (
+	"net"
+)
+
+// The IANA assigned destination port for Geneve.
+const GenevePort = 6081
+
+// Optional parameters used when creating a Geneve interface.
+// Fields left at their zero value use the system defaults.
+type GeneveOptions struct {
+	Remote     net.IP // Address of the remote tunnel endpoint, required unless External.
+	Port       int    // Destination UDP port (default: GenevePort).
+	TTL        int    // TTL of encapsulated packets.
+	TTLInherit bool   // Copy the TTL of the inner packet, exclusive with TTL.
+	TOS        int    // TOS of encapsulated packets.
+	TOSInherit bool   // Copy the TOS of the inner packet, exclusive with TOS.
+
+	// Collect the tunnel metadata of each packet instead of using a fixed
+	// remote and VNI, leaving them to be set by routes or eBPF programs.
+	External bool
+}
+
+// Describes the configuration of a Geneve interface. A TTL of 0 is either
+// inherited from the inner packet or the system default.
+type GeneveInfo struct {
+	VNI        int
+	Remote     net.IP
+	Port       int
+	TTL        int
+	TOS        int
+	TOSInherit bool
+	External   bool
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2016 Arroyo Networks, LLC
+Copyright 2019 Project Contributors (See AUTHORS File).
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package splice
+
+import (
+	"errors"
+	"fmt"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netlink/nl"
+	"golang.org/x/sys/unix"
+	"net"
+)
+
+// Provides Geneve interface management for Linux using netlink.
+
+// The TOS value requesting the TOS to be copied from the inner packet.
+const geneveTOSInherit = 1
+
+// Returns the Geneve configuration of a netlink Geneve link.
+func geneveFromNetlink(geneve *netlink.Geneve) *GeneveInfo {
+
+	info := &GeneveInfo{
+		VNI:      int(geneve.ID),
+		Port:     int(geneve.Dport),
+		TTL:      int(geneve.Ttl),
+		TOS:      int(geneve.Tos),
+		External: geneve.FlowBased,
+	}
+
+	if geneve.Remote != nil && !geneve.Remote.IsUnspecified() {
+		info.Remote = geneve.Remote
+	}
+	if info.TOS == geneveTOSInherit {
+		info.TOS = 0
+		info.TOSInherit = true
+	}
+
+	return info
+}
+
+// Creates a Geneve interface with the given virtual network identifier,
+// tunnelling to a single remote endpoint. In external mode no VNI or remote
+// is given and both are taken from the metadata of each packet instead. The
+// interface is created administratively down.
+// This is equivalent to 'ip link add <name> type geneve id <vni> remote
+// <remote>'.
+func LinkCreateGeneve(name string, vni int, opts *GeneveOptions) (*net.Interface, error) {
+
+	if vni < 0 || vni > 0xffffff {
+		return nil, errors.New("Geneve network identifier must be between 0 and 16777215")
+	}
+
+	if opts == nil {
+		opts = &GeneveOptions{}
+	}
+
+	if opts.External && (vni != 0 || opts.Remote != nil) {
+		return nil, errors.New("External mode does not take a network identifier or remote address")
+	}
+	if !opts.External && opts.Remote == nil {
+		return nil, errors.New("Remote address is required unless in external mode")
+	}
+	if opts.TTLInherit && opts.TTL != 0 {
+		return nil, errors.New("TTL and TTL inheritance are mutually exclusive")
+	}
+	if opts.TOSInherit && opts.TOS != 0 {
+		return nil, errors.New("TOS and TOS inheritance are mutually exclusive")
+	}
+
+	// The netlink library has no support for TTL inheritance, so the request
+	// is built here.
+	req := nl.NewNetlinkRequest(unix.RTM_NEWLINK, unix.NLM_F_CREATE|unix.NLM_F_EXCL|unix.NLM_F_ACK)
+	req.AddData(nl.NewIfInfomsg(unix.AF_UNSPEC))
+	req.AddData(nl.NewRtAttr(unix.IFLA_IFNAME, nl.ZeroTerminated(name)))
+
+	linkInfo := nl.NewRtAttr(unix.IFLA_LINKINFO, nil)
+	linkInfo.AddRtAttr(nl.IFLA_INFO_KIND, nl.NonZeroTerminated("geneve"))
+	data := linkInfo.AddRtAttr(nl.IFLA_INFO_DATA, nil)
+
+	if opts.External {
+		data.AddRtAttr(nl.IFLA_GENEVE_COLLECT_METADATA, []byte{})
+	} else {
+		data.AddRtAttr(nl.IFLA_GENEVE_ID, nl.Uint32Attr(uint32(vni)))
+		if ip4 := opts.Remote.To4(); ip4 != nil {
+			data.AddRtAttr(nl.IFLA_GENEVE_REMOTE, ip4)
+		} else {
+			data.AddRtAttr(nl.IFLA_GENEVE_REMOTE6, opts.Remote.To16())
+		}
+	}
+
+	if opts.Port != 0 {
+		data.AddRtAttr(nl.IFLA_GENEVE_PORT, nl.Uint16Attr(nl.Swap16(uint16(opts.Port))))
+	}
+	if opts.TTLInherit {
+		data.AddRtAttr(nl.IFLA_GENEVE_TTL_INHERIT, nl.Uint8Attr(1))
+	} else if opts.TTL != 0 {
+		data.AddRtAttr(nl.IFLA_GENEVE_TTL, nl.Uint8Attr(uint8(opts.TTL)))
+	}
+	if opts.TOSInherit {
+		data.AddRtAttr(nl.IFLA_GENEVE_TOS, nl.Uint8Attr(geneveTOSInherit))
+	} else if opts.TOS != 0 {
+		data.AddRtAttr(nl.IFLA_GENEVE_TOS, nl.Uint8Attr(uint8(opts.TOS)))
+	}
+
+	req.AddData(linkInfo)
+
+	if _, err := req.Execute(unix.NETLINK_ROUTE, 0); err != nil {
+		if errors.Is(err, unix.EEXIST) {
+			return nil, fmt.Errorf("link %q: %w", name, ErrExists)
+		}
+		return nil, err
+	}
+
+	return net.InterfaceByName(name)
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2016 Arroyo Networks, LLC
+Copyright 2019 Project Contributors (See AUTHORS File).
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package splice_test
+
+import (
+	"github.com/arroyonetworks/splice"
+	"net"
+	"testing"
+)
+
+// ============================================================================
+//	LinkCreateGeneve
+// ============================================================================
+
+func TestLinkCreateGeneve(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	remote := net.ParseIP("192.0.2.1")
+
+	// (1)	Create a Geneve Interface to a Remote Endpoint
+	//			Expect: No error
+	// ------------------------------------------------------------------------
+
+	opts := &splice.GeneveOptions{
+		Remote:     remote,
+		Port:       6082,
+		TTL:        32,
+		TOSInherit: true,
+	}
+
+	intf, err := splice.LinkCreateGeneve(RandomIntfName("geneve"), 100, opts)
+	SkipIfNotSupported(t, err)
+	if err != nil {
+		t.Fatal("LinkCreateGeneve Returned Error: ", err)
+	}
+
+	// (2)	Get the Link
+	//			Expect: The Geneve configuration is reported
+	// ------------------------------------------------------------------------
+
+	link, err := splice.LinkGet(intf)
+	if err != nil {
+		t.Fatal("LinkGet Returned Error: ", err)
+	}
+
+	if link.Geneve == nil {
+		t.Fatal("Link Is Not a Geneve Interface: ", link.Kind)
+	}
+	if link.Geneve.VNI != 100 || !link.Geneve.Remote.Equal(remote) {
+		t.Fatal("Geneve Has Unexpected VNI or Remote: ", link.Geneve.VNI, link.Geneve.Remote)
+	}
+	if link.Geneve.Port != 6082 || link.Geneve.TTL != 32 || !link.Geneve.TOSInherit {
+		t.Fatal("Geneve Has Unexpected Options: ", *link.Geneve)
+	}
+}
+
+func TestLinkCreateGeneve_External(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Create a Geneve Interface in External Mode
+	//			Expect: No error
+	// ------------------------------------------------------------------------
+
+	opts := &splice.GeneveOptions{
+		External:   true,
+		TTLInherit: true,
+	}
+
+	intf, err := splice.LinkCreateGeneve(RandomIntfName("geneve"), 0, opts)
+	SkipIfNotSupported(t, err)
+	if err != nil {
+		t.Fatal("LinkCreateGeneve Returned Error: ", err)
+	}
+
+	// (2)	Get the Link
+	//			Expect: External mode on the default port is reported
+	// ------------------------------------------------------------------------
+
+	link, err := splice.LinkGet(intf)
+	if err != nil {
+		t.Fatal("LinkGet Returned Error: ", err)
+	}
+
+	if link.Geneve == nil || !link.Geneve.External {
+		t.Fatal("Link Is Not an External Geneve Interface: ", link.Kind)
+	}
+	if link.Geneve.Port != splice.GenevePort {
+		t.Fatal("Geneve Has Unexpected Port: ", link.Geneve.Port)
+	}
+}
+
+func TestLinkCreateGeneve_InvalidOptions(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	remote := net.ParseIP("192.0.2.1")
+
+	// (1)	Create a Geneve Interface Without a Remote
+	//			Expect: Error
+	// ------------------------------------------------------------------------
+
+	if _, err := splice.LinkCreateGeneve(RandomIntfName("geneve"), 100, nil); err == nil {
+		t.Fatal("LinkCreateGeneve Did Not Return an Error Without a Remote")
+	}
+
+	// (2)	Create an External Geneve Interface With a Remote
+	//			Expect: Error
+	// ------------------------------------------------------------------------
+
+	opts := &splice.GeneveOptions{Remote: remote, External: true}
+
+	if _, err := splice.LinkCreateGeneve(RandomIntfName("geneve"), 0, opts); err == nil {
+		t.Fatal("LinkCreateGeneve Did Not Return an Error with External Mode and a Remote")
+	}
+
+	// (3)	Create a Geneve Interface With Both a TTL and TTL Inheritance
+	//			Expect: Error
+	// ------------------------------------------------------------------------
+
+	opts = &splice.GeneveOptions{Remote: remote, TTL: 64, TTLInherit: true}
+
+	if _, err := splice.LinkCreateGeneve(RandomIntfName("geneve"), 100, opts); err == nil {
+		t.Fatal("LinkCreateGeneve Did Not Return an Error with Conflicting TTL Options")
+	}
+
+	// (4)	Create a Geneve Interface With an Out of Range VNI
+	//			Expect: Error
+	// ------------------------------------------------------------------------
+
+	opts = &splice.GeneveOptions{Remote: remote}
+
+	if _, err := splice.LinkCreateGeneve(RandomIntfName("geneve"), 1<<24, opts); err == nil {
+		t.Fatal("LinkCreateGeneve Did Not Return an Error with Invalid VNI")
+	}
+}
@@ -1,9 +1,9 @@
 module github.com/arroyonetworks/splice
 
-go 1.13
+go 1.17
 
 require (
-	github.com/vishvananda/netlink v1.0.1-0.20190930145447-2ec5bdc52b86
-	github.com/vishvananda/netns v0.0.0-20190625233234-7109fa855b0f
-	golang.org/x/sys v0.0.0-20191002091554-b397fe3ad8ed
+	github.com/vishvananda/netlink v1.3.1
+	github.com/vishvananda/netns v0.0.5
+	golang.org/x/sys v0.10.0
 )
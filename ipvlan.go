@@ -0,0 +1,50 @@
+/*
+Copyright 2016 Arroyo Networks, LLC
+Copyright 2019 Project Contributors (See AUTHORS File).
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package splice
+
+import (
+	"strconv"
+)
+
+// The mode of an IPVLAN interface, selecting the layer at which packets are
+// switched between the parent and its IPVLAN interfaces.
+type IPVLANMode int
+
+const (
+	IPVLANModeL2  IPVLANMode = iota + 1 // Switched by MAC address, sharing the parent's.
+	IPVLANModeL3                        // Routed by IP address, without broadcast.
+	IPVLANModeL3S                       // Routed by IP address, passing through netfilter.
+)
+
+var ipvlanModeNames = map[IPVLANMode]string{
+	IPVLANModeL2:  "l2",
+	IPVLANModeL3:  "l3",
+	IPVLANModeL3S: "l3s",
+}
+
+func (m IPVLANMode) String() string {
+	if name, ok := ipvlanModeNames[m]; ok {
+		return name
+	}
+	return strconv.Itoa(int(m))
+}
+
+// Describes the configuration of an IPVLAN interface.
+type IPVLANInfo struct {
+	Mode IPVLANMode
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2016 Arroyo Networks, LLC
+Copyright 2019 Project Contributors (See AUTHORS File).
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package splice
+
+import (
+	"errors"
+	"github.com/vishvananda/netlink"
+	"net"
+)
+
+// Provides IPVLAN interface management for Linux using netlink.
+
+var ipvlanModeValues = map[IPVLANMode]netlink.IPVlanMode{
+	IPVLANModeL2:  netlink.IPVLAN_MODE_L2,
+	IPVLANModeL3:  netlink.IPVLAN_MODE_L3,
+	IPVLANModeL3S: netlink.IPVLAN_MODE_L3S,
+}
+
+// Returns the IPVLAN configuration of a netlink IPVLAN link.
+func ipvlanFromNetlink(ipvlan *netlink.IPVlan) *IPVLANInfo {
+
+	for mode, value := range ipvlanModeValues {
+		if value == ipvlan.Mode {
+			return &IPVLANInfo{Mode: mode}
+		}
+	}
+
+	return &IPVLANInfo{}
+}
+
+// Creates an IPVLAN interface on top of the given parent interface, sharing
+// the parent's MAC address. A mode of 0 uses IPVLANModeL3, the system
+// default. The interface is created administratively down.
+// This is equivalent to 'ip link add link <parent.Name> name <name> type
+// ipvlan mode <mode>'.
+func LinkCreateIPVLAN(parent *net.Interface, name string, mode IPVLANMode) (*net.Interface, error) {
+
+	if mode == 0 {
+		mode = IPVLANModeL3
+	}
+
+	value, ok := ipvlanModeValues[mode]
+	if !ok {
+		return nil, errors.New("Unsupported IPVLAN mode")
+	}
+
+	attrs := netlink.NewLinkAttrs()
+	attrs.Name = name
+	attrs.ParentIndex = parent.Index
+
+	return linkAdd(&netlink.IPVlan{
+		LinkAttrs: attrs,
+		Mode:      value,
+	})
+}
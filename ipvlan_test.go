@@ -0,0 +1,78 @@
+/*
+Copyright 2016 Arroyo Networks, LLC
+Copyright 2019 Project Contributors (See AUTHORS File).
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package splice_test
+
+import (
+	"github.com/arroyonetworks/splice"
+	"testing"
+)
+
+// ============================================================================
+//	LinkCreateIPVLAN
+// ============================================================================
+
+func TestLinkCreateIPVLAN(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	parent := GetDummyUpIntf(t)
+
+	// (1)	Create an IPVLAN Interface in L2 Mode
+	//			Expect: No error
+	// ------------------------------------------------------------------------
+
+	intf, err := splice.LinkCreateIPVLAN(parent, RandomIntfName("ipvlan"), splice.IPVLANModeL2)
+	SkipIfNotSupported(t, err)
+	if err != nil {
+		t.Fatal("LinkCreateIPVLAN Returned Error: ", err)
+	}
+
+	// (2)	Get the Link
+	//			Expect: An L2 mode IPVLAN on the parent is reported
+	// ------------------------------------------------------------------------
+
+	link, err := splice.LinkGet(intf)
+	if err != nil {
+		t.Fatal("LinkGet Returned Error: ", err)
+	}
+
+	if link.IPVLAN == nil || link.ParentIndex != parent.Index {
+		t.Fatal("Link Is Not an IPVLAN of the Parent: ", link.Kind, link.ParentIndex)
+	}
+	if link.IPVLAN.Mode != splice.IPVLANModeL2 {
+		t.Fatal("IPVLAN Has Unexpected Mode: ", link.IPVLAN.Mode)
+	}
+	if link.Interface.HardwareAddr.String() != parent.HardwareAddr.String() {
+		t.Fatal("IPVLAN Does Not Share the Parent's MAC Address")
+	}
+}
+
+func TestLinkCreateIPVLAN_InvalidMode(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Create an IPVLAN Interface with an Unknown Mode
+	//			Expect: Error
+	// ------------------------------------------------------------------------
+
+	if _, err := splice.LinkCreateIPVLAN(config.loopbackIntf, RandomIntfName("ipvlan"), 42); err == nil {
+		t.Fatal("LinkCreateIPVLAN Did Not Return an Error with Invalid Mode")
+	}
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2016 Arroyo Networks, LLC
+Copyright 2019 Project Contributors (See AUTHORS File).
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package splice
+
+import (
+	"net"
+	"strconv"
+	"strings"
+)
+
+// The operational state of a link, as defined by RFC 2863.
+type LinkOperState int
+
+const (
+	LinkOperUnknown        LinkOperState = 0
+	LinkOperNotPresent     LinkOperState = 1
+	LinkOperDown           LinkOperState = 2
+	LinkOperLowerLayerDown LinkOperState = 3
+	LinkOperTesting        LinkOperState = 4
+	LinkOperDormant        LinkOperState = 5
+	LinkOperUp             LinkOperState = 6
+)
+
+var linkOperStateNames = map[LinkOperState]string{
+	LinkOperUnknown:        "unknown",
+	LinkOperNotPresent:     "notpresent",
+	LinkOperDown:           "down",
+	LinkOperLowerLayerDown: "lowerlayerdown",
+	LinkOperTesting:        "testing",
+	LinkOperDormant:        "dormant",
+	LinkOperUp:             "up",
+}
+
+func (s LinkOperState) String() string {
+	if name, ok := linkOperStateNames[s]; ok {
+		return name
+	}
+	return strconv.Itoa(int(s))
+}
+
+// The kind of change reported by a LinkEvent.
+type LinkEventType int
+
+const (
+	LinkEventAdd    LinkEventType = iota + 1 // A link was created.
+	LinkEventDelete                          // A link was removed.
+	LinkEventChange                          // An attribute of an existing link changed.
+)
+
+var linkEventTypeNames = map[LinkEventType]string{
+	LinkEventAdd:    "add",
+	LinkEventDelete: "delete",
+	LinkEventChange: "change",
+}
+
+func (t LinkEventType) String() string {
+	if name, ok := linkEventTypeNames[t]; ok {
+		return name
+	}
+	return strconv.Itoa(int(t))
+}
+
+// A set of link attributes which changed, reported by a LinkEvent.
+type LinkChange uint
+
+const (
+	LinkChangeAdminState LinkChange = 1 << iota // The link was brought up or down.
+	LinkChangeOperState                         // The operational state changed.
+	LinkChangeCarrier                           // The carrier was gained or lost.
+	LinkChangeMTU                               // The MTU changed.
+	LinkChangeName                              // The link was renamed.
+)
+
+var linkChangeNames = []string{"admin", "oper", "carrier", "mtu", "name"}
+
+func (c LinkChange) String() string {
+	var names []string
+	for i, name := range linkChangeNames {
+		if c&(1<<uint(i)) != 0 {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return "none"
+	}
+	return strings.Join(names, "|")
+}
+
+// A change to a network link, delivered by LinkSubscribe.
+// Interface and the state fields describe the link after the change.
+type LinkEvent struct {
+	Type      LinkEventType
+	Changes   LinkChange // Only set for LinkEventChange.
+	Interface *net.Interface
+	OperState LinkOperState
+	Carrier   bool
+}
+
+// A network link along with its type-specific configuration.
+type Link struct {
+	Interface   *net.Interface
+	Kind        string // Link type, such as "veth", "bridge" or "vlan".
+	ParentIndex int    // Index of the underlying link, 0 if none.
+	MasterIndex int    // Index of the link this link is enslaved to, 0 if none.
+	OperState   LinkOperState
+	Carrier     bool
+	Alias       string       // Free-form description of the link.
+	TxQueueLen  int          // Length of the transmit queue.
+	VLAN        *VLANInfo    // Set for VLAN links.
+	VXLAN       *VXLANInfo   // Set for VXLAN links.
+	MACVLAN     *MACVLANInfo // Set for MACVLAN links.
+	IPVLAN      *IPVLANInfo  // Set for IPVLAN links.
+	Bond        *BondInfo    // Set for bond links.
+	TunTap      *TunTapInfo  // Set for TUN/TAP links.
+	Tunnel      *TunnelInfo  // Set for GRE, IPIP, SIT and ip6tnl tunnel links.
+	VRF         *VRFInfo     // Set for VRF links.
+	Geneve      *GeneveInfo  // Set for Geneve links.
+	BareUDP     *BareUDPInfo // Set for bareudp links.
+}
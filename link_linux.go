@@ -18,8 +18,14 @@ limitations under the License.
 package splice
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netns"
+	"golang.org/x/sys/unix"
 	"net"
+	"strings"
 )
 
 // Provides network link manipulation for Linux using netlink.
@@ -51,3 +57,355 @@ func LinkBringDown(intf *net.Interface) error {
 	return err
 
 }
+
+// The subset of link attributes tracked to detect changes.
+type linkState struct {
+	flags   net.Flags
+	oper    LinkOperState
+	carrier bool
+	mtu     int
+	name    string
+}
+
+// Returns the tracked state of a netlink link.
+func linkStateFromNetlink(attrs *netlink.LinkAttrs) linkState {
+	return linkState{
+		flags:   attrs.Flags,
+		oper:    LinkOperState(attrs.OperState),
+		carrier: attrs.RawFlags&unix.IFF_LOWER_UP != 0,
+		mtu:     attrs.MTU,
+		name:    attrs.Name,
+	}
+}
+
+// Returns the attributes which differ between two link states.
+func (s linkState) changes(previous linkState) LinkChange {
+
+	var changes LinkChange
+
+	if s.flags&net.FlagUp != previous.flags&net.FlagUp {
+		changes |= LinkChangeAdminState
+	}
+	if s.oper != previous.oper {
+		changes |= LinkChangeOperState
+	}
+	if s.carrier != previous.carrier {
+		changes |= LinkChangeCarrier
+	}
+	if s.mtu != previous.mtu {
+		changes |= LinkChangeMTU
+	}
+	if s.name != previous.name {
+		changes |= LinkChangeName
+	}
+
+	return changes
+}
+
+// Returns a net.Interface describing a netlink link.
+func interfaceFromNetlink(attrs *netlink.LinkAttrs) *net.Interface {
+	return &net.Interface{
+		Index:        attrs.Index,
+		MTU:          attrs.MTU,
+		Name:         attrs.Name,
+		HardwareAddr: attrs.HardwareAddr,
+		Flags:        attrs.Flags,
+	}
+}
+
+// Converts a netlink link update into a splice LinkEvent, using and updating
+// the cache of known link states. Returns false if nothing of interest
+// changed.
+func linkEventFromNetlink(update *netlink.LinkUpdate, known map[int]linkState) (LinkEvent, bool) {
+
+	attrs := update.Attrs()
+	state := linkStateFromNetlink(attrs)
+
+	event := LinkEvent{
+		Type:      LinkEventChange,
+		Interface: interfaceFromNetlink(attrs),
+		OperState: state.oper,
+		Carrier:   state.carrier,
+	}
+
+	previous, ok := known[attrs.Index]
+
+	switch {
+	case update.Header.Type == unix.RTM_DELLINK:
+		delete(known, attrs.Index)
+		event.Type = LinkEventDelete
+		return event, true
+	// Newly registered links are announced with every flag marked as changed.
+	case !ok || update.IfInfomsg.Change == ^uint32(0):
+		event.Type = LinkEventAdd
+	default:
+		event.Changes = state.changes(previous)
+	}
+
+	known[attrs.Index] = state
+
+	return event, event.Type != LinkEventChange || event.Changes != 0
+}
+
+// Subscribes to changes of the system's network links. Events are reported
+// when a link is created or removed, and when its administrative state,
+// operational state, carrier, MTU or name changes. Events are delivered on
+// the returned channel until the context is cancelled, after which the
+// channel is closed. The channel is also closed if the subscription fails.
+// This is equivalent to 'ip monitor link'.
+func LinkSubscribe(ctx context.Context) (<-chan LinkEvent, error) {
+
+	updates := make(chan netlink.LinkUpdate)
+	done := make(chan struct{})
+
+	if err := netlink.LinkSubscribeWithOptions(updates, done, netlink.LinkSubscribeOptions{}); err != nil {
+		return nil, err
+	}
+
+	// Links which exist before the subscription must be known, so that
+	// their first update is reported as a change rather than a creation.
+	links, err := netlink.LinkList()
+	if err != nil {
+		go subscriptionStop(updates, done)
+		return nil, err
+	}
+
+	known := make(map[int]linkState, len(links))
+	for _, link := range links {
+		known[link.Attrs().Index] = linkStateFromNetlink(link.Attrs())
+	}
+
+	events := make(chan LinkEvent)
+
+	go subscriptionForward(ctx, updates, done, events, func(u interface{}) (interface{}, bool) {
+		update := u.(netlink.LinkUpdate)
+		return linkEventFromNetlink(&update, known)
+	})
+
+	return events, nil
+}
+
+// Adds the given link, returning the created interface.
+func linkAdd(link netlink.Link) (*net.Interface, error) {
+
+	name := link.Attrs().Name
+
+	if err := netlink.LinkAdd(link); err != nil {
+		if errors.Is(err, unix.EEXIST) {
+			return nil, fmt.Errorf("link %q: %w", name, ErrExists)
+		}
+		return nil, err
+	}
+
+	return net.InterfaceByName(name)
+}
+
+// Returns a handle to a network namespace, given either its name as used by
+// 'ip netns' or a path to a namespace file such as /proc/<pid>/ns/net.
+func namespaceByName(namespace string) (netns.NsHandle, error) {
+	if strings.ContainsRune(namespace, '/') {
+		return netns.GetFromPath(namespace)
+	}
+	return netns.GetFromName(namespace)
+}
+
+// Creates a dummy interface. The interface is created administratively down.
+// This is equivalent to 'ip link add <name> type dummy'.
+func LinkCreateDummy(name string) (*net.Interface, error) {
+
+	attrs := netlink.NewLinkAttrs()
+	attrs.Name = name
+
+	return linkAdd(&netlink.Dummy{LinkAttrs: attrs})
+}
+
+// Creates a pair of connected virtual ethernet interfaces, returning the
+// interface named nameA. If peerNamespace is given, the peer nameB is moved
+// into that network namespace, given either by its name as used by 'ip netns'
+// or a path to a namespace file. Both interfaces are created administratively
+// down.
+// This is equivalent to 'ip link add <nameA> type veth peer name <nameB>
+// netns <peerNamespace>'.
+func LinkCreateVeth(nameA string, nameB string, peerNamespace string) (*net.Interface, error) {
+
+	attrs := netlink.NewLinkAttrs()
+	attrs.Name = nameA
+
+	veth := &netlink.Veth{
+		LinkAttrs: attrs,
+		PeerName:  nameB,
+	}
+
+	if peerNamespace != "" {
+		ns, err := namespaceByName(peerNamespace)
+		if err != nil {
+			return nil, err
+		}
+		defer ns.Close()
+
+		veth.PeerNamespace = netlink.NsFd(ns)
+	}
+
+	return linkAdd(veth)
+}
+
+// Creates a bridge interface. The interface is created administratively
+// down.
+// This is equivalent to 'ip link add <name> type bridge'.
+func LinkCreateBridge(name string) (*net.Interface, error) {
+
+	attrs := netlink.NewLinkAttrs()
+	attrs.Name = name
+
+	return linkAdd(&netlink.Bridge{LinkAttrs: attrs})
+}
+
+// Deletes the given network interface. Deleting either end of a veth pair
+// removes both interfaces.
+// This is equivalent to 'ip link del <intf.Name>'.
+func LinkDelete(intf *net.Interface) error {
+
+	var err error
+	var link netlink.Link
+
+	if link, err = netlink.LinkByIndex(intf.Index); err == nil {
+		return netlink.LinkDel(link)
+	}
+
+	return err
+}
+
+// Converts a netlink link into a splice Link.
+func linkFromNetlink(link netlink.Link) *Link {
+
+	attrs := link.Attrs()
+	state := linkStateFromNetlink(attrs)
+
+	result := &Link{
+		Interface:   interfaceFromNetlink(attrs),
+		Kind:        link.Type(),
+		ParentIndex: attrs.ParentIndex,
+		MasterIndex: attrs.MasterIndex,
+		OperState:   state.oper,
+		Carrier:     state.carrier,
+		Alias:       attrs.Alias,
+		TxQueueLen:  attrs.TxQLen,
+	}
+
+	switch l := link.(type) {
+	case *netlink.Vlan:
+		result.VLAN = vlanFromNetlink(l)
+	case *netlink.Vxlan:
+		result.VXLAN = vxlanFromNetlink(l)
+	case *netlink.Macvlan:
+		result.MACVLAN = macvlanFromNetlink(l)
+	case *netlink.IPVlan:
+		result.IPVLAN = ipvlanFromNetlink(l)
+	case *netlink.Bond:
+		result.Bond = bondFromNetlink(l)
+	case *netlink.Tuntap:
+		result.TunTap = tunTapFromNetlink(l)
+	case *netlink.Gretun, *netlink.Gretap, *netlink.Iptun, *netlink.Sittun, *netlink.Ip6tnl:
+		result.Tunnel = tunnelFromNetlink(l)
+	case *netlink.Vrf:
+		result.VRF = vrfFromNetlink(l)
+	case *netlink.Geneve:
+		result.Geneve = geneveFromNetlink(l)
+	case *netlink.BareUDP:
+		result.BareUDP = bareUDPFromNetlink(l)
+	}
+
+	return result
+}
+
+// Returns all network links on the system.
+// This is equivalent to 'ip -details link show'.
+func LinkList() ([]*Link, error) {
+
+	links, err := netlink.LinkList()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*Link, 0, len(links))
+	for _, link := range links {
+		result = append(result, linkFromNetlink(link))
+	}
+
+	return result, nil
+}
+
+// Returns the link of the given network interface.
+// This is equivalent to 'ip -details link show <intf.Name>'.
+func LinkGet(intf *net.Interface) (*Link, error) {
+
+	link, err := netlink.LinkByIndex(intf.Index)
+	if err != nil {
+		return nil, err
+	}
+
+	return linkFromNetlink(link), nil
+}
+
+// Applies the given change to the link of the network interface, returning
+// the interface as refreshed after the change.
+func linkSet(intf *net.Interface, set func(link netlink.Link) error) (*net.Interface, error) {
+
+	link, err := netlink.LinkByIndex(intf.Index)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := set(link); err != nil {
+		return nil, err
+	}
+
+	return net.InterfaceByIndex(intf.Index)
+}
+
+// Sets the MTU of the given network interface.
+// This is equivalent to 'ip link set <intf.Name> mtu <mtu>'.
+func LinkSetMTU(intf *net.Interface, mtu int) (*net.Interface, error) {
+	return linkSet(intf, func(link netlink.Link) error {
+		return netlink.LinkSetMTU(link, mtu)
+	})
+}
+
+// Sets the MAC address of the given network interface. Some drivers require
+// the interface to be down to change its address.
+// This is equivalent to 'ip link set <intf.Name> address <hwAddr>'.
+func LinkSetHardwareAddr(intf *net.Interface, hwAddr net.HardwareAddr) (*net.Interface, error) {
+	return linkSet(intf, func(link netlink.Link) error {
+		return netlink.LinkSetHardwareAddr(link, hwAddr)
+	})
+}
+
+// Renames the given network interface. The interface must be down.
+// ErrExists is returned if another interface already has the name.
+// This is equivalent to 'ip link set <intf.Name> name <name>'.
+func LinkRename(intf *net.Interface, name string) (*net.Interface, error) {
+	return linkSet(intf, func(link netlink.Link) error {
+		err := netlink.LinkSetName(link, name)
+		if errors.Is(err, unix.EEXIST) {
+			return fmt.Errorf("link %q: %w", name, ErrExists)
+		}
+		return err
+	})
+}
+
+// Sets the alias of the given network interface, a free-form description
+// such as the purpose of the interface. An empty alias clears it.
+// This is equivalent to 'ip link set <intf.Name> alias <alias>'.
+func LinkSetAlias(intf *net.Interface, alias string) (*net.Interface, error) {
+	return linkSet(intf, func(link netlink.Link) error {
+		return netlink.LinkSetAlias(link, alias)
+	})
+}
+
+// Sets the length of the transmit queue of the given network interface.
+// This is equivalent to 'ip link set <intf.Name> txqueuelen <length>'.
+func LinkSetTxQueueLen(intf *net.Interface, length int) (*net.Interface, error) {
+	return linkSet(intf, func(link netlink.Link) error {
+		return netlink.LinkSetTxQLen(link, length)
+	})
+}
@@ -17,9 +17,12 @@ limitations under the License.
 package splice_test
 
 import (
+	"context"
+	"errors"
 	"github.com/arroyonetworks/splice"
 	"net"
 	"testing"
+	"time"
 )
 
 // ============================================================================
@@ -114,3 +117,495 @@ func TestLinkBringDown_InvalidIntfValue(t *testing.T) {
 	}
 
 }
+
+// ============================================================================
+//	LinkSubscribe
+// ============================================================================
+
+// Waits for an event of the given type for the named interface, ignoring any
+// unrelated events received in the meantime.
+func waitLinkEvent(t *testing.T, events <-chan splice.LinkEvent, eventType splice.LinkEventType, name string) *splice.LinkEvent {
+
+	timeout := time.After(5 * time.Second)
+
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				t.Fatal("Link Event Channel Closed Unexpectedly")
+			}
+			if event.Type == eventType && event.Interface.Name == name {
+				return &event
+			}
+		case <-timeout:
+			t.Fatalf("Timed Out Waiting for Link Event (%s %s)", eventType, name)
+		}
+	}
+}
+
+func TestLinkSubscribe(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// (1)	Subscribe to Link Events
+	//			Expect: No error
+	// ------------------------------------------------------------------------
+
+	events, err := splice.LinkSubscribe(ctx)
+	if err != nil {
+		t.Fatal("LinkSubscribe Returned Error: ", err)
+	}
+
+	// (2)	Create a Downed Link
+	//			Expect: An add event for the link
+	// ------------------------------------------------------------------------
+
+	intf := GetDummyDownIntf(t)
+
+	event := waitLinkEvent(t, events, splice.LinkEventAdd, intf.Name)
+	if event.Interface.Index != intf.Index {
+		t.Fatal("Add Event Has Unexpected Interface Index: ", event.Interface.Index)
+	}
+
+	// (3)	Bring the Link Up
+	//			Expect: A change event reporting the administrative state
+	// ------------------------------------------------------------------------
+
+	if err := splice.LinkBringUp(intf); err != nil {
+		t.Fatal("LinkBringUp Returned Error: ", err)
+	}
+
+	event = waitLinkEvent(t, events, splice.LinkEventChange, intf.Name)
+	if event.Changes&splice.LinkChangeAdminState == 0 {
+		t.Fatal("Change Event Does Not Report Administrative State: ", event.Changes)
+	}
+	if !IntfIsUp(event.Interface) {
+		t.Fatal("Change Event Does Not Report the Link as Up")
+	}
+
+	// (4)	Cancel the Context
+	//			Expect: The event channel is closed
+	// ------------------------------------------------------------------------
+
+	cancel()
+
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case _, ok := <-events:
+			if !ok {
+				return
+			}
+		case <-timeout:
+			t.Fatal("Link Event Channel Was Not Closed After Cancel")
+		}
+	}
+}
+
+// ============================================================================
+//	LinkCreateDummy
+// ============================================================================
+
+func TestLinkCreateDummy(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Create a Dummy Link
+	//			Expect: No error
+	// ------------------------------------------------------------------------
+
+	name := RandomIntfName("dummy")
+
+	intf, err := splice.LinkCreateDummy(name)
+	SkipIfNotSupported(t, err)
+	if err != nil {
+		t.Fatal("LinkCreateDummy Returned Error: ", err)
+	}
+
+	// (2)	Expect: The link exists and is down
+	// ------------------------------------------------------------------------
+
+	if intf.Name != name || !IntfExists(name) {
+		t.Fatal("Created Dummy Link Does Not Exist")
+	}
+	if !IntfIsDown(intf) {
+		t.Fatal("Created Dummy Link is Not Down")
+	}
+}
+
+// ============================================================================
+//	LinkCreateVeth
+// ============================================================================
+
+func TestLinkCreateVeth(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Create a Veth Pair
+	//			Expect: No error
+	// ------------------------------------------------------------------------
+
+	nameA := RandomIntfName("vetha")
+	nameB := RandomIntfName("vethb")
+
+	intf, err := splice.LinkCreateVeth(nameA, nameB, "")
+	if err != nil {
+		t.Fatal("LinkCreateVeth Returned Error: ", err)
+	}
+
+	// (2)	Expect: Both ends of the pair exist
+	// ------------------------------------------------------------------------
+
+	if intf.Name != nameA || !IntfExists(nameA) {
+		t.Fatal("First Veth Link Does Not Exist")
+	}
+	if !IntfExists(nameB) {
+		t.Fatal("Peer Veth Link Does Not Exist")
+	}
+}
+
+func TestLinkCreateVeth_InvalidNamespace(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Create a Veth Pair with a Missing Peer Namespace
+	//			Expect: Error, and no link is created
+	// ------------------------------------------------------------------------
+
+	nameA := RandomIntfName("vetha")
+	nameB := RandomIntfName("vethb")
+
+	if _, err := splice.LinkCreateVeth(nameA, nameB, "/nonexistent/ns/net"); err == nil {
+		t.Fatal("LinkCreateVeth Did Not Return an Error with Invalid Namespace")
+	}
+
+	if IntfExists(nameA) {
+		t.Fatal("Veth Link Was Created Despite Invalid Namespace")
+	}
+}
+
+// ============================================================================
+//	LinkCreateBridge
+// ============================================================================
+
+func TestLinkCreateBridge(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Create a Bridge
+	//			Expect: No error
+	// ------------------------------------------------------------------------
+
+	name := RandomIntfName("br")
+
+	intf, err := splice.LinkCreateBridge(name)
+	if err != nil {
+		t.Fatal("LinkCreateBridge Returned Error: ", err)
+	}
+
+	// (2)	Expect: The bridge exists
+	// ------------------------------------------------------------------------
+
+	if intf.Name != name || !IntfExists(name) {
+		t.Fatal("Created Bridge Does Not Exist")
+	}
+}
+
+func TestLinkCreateBridge_Exists(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Create a Bridge
+	// ------------------------------------------------------------------------
+
+	name := RandomIntfName("br")
+
+	if _, err := splice.LinkCreateBridge(name); err != nil {
+		t.Fatal("LinkCreateBridge Returned Error: ", err)
+	}
+
+	// (2)	Create the Same Bridge Again
+	//			Expect: ErrExists
+	// ------------------------------------------------------------------------
+
+	if _, err := splice.LinkCreateBridge(name); !errors.Is(err, splice.ErrExists) {
+		t.Fatal("LinkCreateBridge Did Not Return an Exists Error: ", err)
+	}
+}
+
+// ============================================================================
+//	LinkDelete
+// ============================================================================
+
+func TestLinkDelete(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Create a Bridge
+	// ------------------------------------------------------------------------
+
+	intf, err := splice.LinkCreateBridge(RandomIntfName("br"))
+	if err != nil {
+		t.Fatal("LinkCreateBridge Returned Error: ", err)
+	}
+
+	// (2)	Delete the Bridge
+	//			Expect: The bridge no longer exists
+	// ------------------------------------------------------------------------
+
+	if err := splice.LinkDelete(intf); err != nil {
+		t.Fatal("LinkDelete Returned Error: ", err)
+	}
+
+	if IntfExists(intf.Name) {
+		t.Fatal("Deleted Link Still Exists")
+	}
+}
+
+func TestLinkDelete_InvalidIntfValue(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Delete an Invalid Link
+	//			Expect: Error since the interface is invalid
+	// ------------------------------------------------------------------------
+
+	intf := &net.Interface{Index: -1}
+	if err := splice.LinkDelete(intf); err == nil {
+		t.Fatal("LinkDelete Did Not Return an Error with Invalid Interface value")
+	}
+}
+
+// ============================================================================
+//	LinkList
+// ============================================================================
+
+func TestLinkList(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Create a Bridge
+	// ------------------------------------------------------------------------
+
+	intf, err := splice.LinkCreateBridge(RandomIntfName("br"))
+	if err != nil {
+		t.Fatal("LinkCreateBridge Returned Error: ", err)
+	}
+
+	// (2)	List the Links
+	//			Expect: The loopback and the bridge are listed
+	// ------------------------------------------------------------------------
+
+	links, err := splice.LinkList()
+	if err != nil {
+		t.Fatal("LinkList Returned Error: ", err)
+	}
+
+	found := map[int]*splice.Link{}
+	for _, link := range links {
+		found[link.Interface.Index] = link
+	}
+
+	if _, ok := found[config.loopbackIntf.Index]; !ok {
+		t.Fatal("Loopback Link Was Not Listed")
+	}
+	if link, ok := found[intf.Index]; !ok || link.Kind != "bridge" {
+		t.Fatal("Bridge Link Was Not Listed as a Bridge: ", link)
+	}
+}
+
+// ============================================================================
+//	LinkGet
+// ============================================================================
+
+func TestLinkGet(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Create a Veth Pair
+	// ------------------------------------------------------------------------
+
+	intf, err := splice.LinkCreateVeth(RandomIntfName("vetha"), RandomIntfName("vethb"), "")
+	if err != nil {
+		t.Fatal("LinkCreateVeth Returned Error: ", err)
+	}
+
+	// (2)	Get the Link
+	//			Expect: The link is a down veth link
+	// ------------------------------------------------------------------------
+
+	link, err := splice.LinkGet(intf)
+	if err != nil {
+		t.Fatal("LinkGet Returned Error: ", err)
+	}
+
+	if link.Interface.Name != intf.Name || link.Kind != "veth" {
+		t.Fatal("LinkGet Returned Unexpected Link: ", link.Interface.Name, link.Kind)
+	}
+	if link.OperState != splice.LinkOperDown {
+		t.Fatal("LinkGet Returned Unexpected Operational State: ", link.OperState)
+	}
+}
+
+func TestLinkGet_InvalidIntfValue(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Get an Invalid Link
+	//			Expect: Error since the interface is invalid
+	// ------------------------------------------------------------------------
+
+	intf := &net.Interface{Index: -1}
+	if _, err := splice.LinkGet(intf); err == nil {
+		t.Fatal("LinkGet Did Not Return an Error with Invalid Interface value")
+	}
+}
+
+// ============================================================================
+//	LinkSetMTU / LinkSetHardwareAddr / LinkSetAlias / LinkSetTxQueueLen
+// ============================================================================
+
+func TestLinkSetAttributes(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Create a Veth Pair
+	// ------------------------------------------------------------------------
+
+	intf, err := splice.LinkCreateVeth(RandomIntfName("vetha"), RandomIntfName("vethb"), "")
+	if err != nil {
+		t.Fatal("LinkCreateVeth Returned Error: ", err)
+	}
+
+	// (2)	Set the MTU
+	//			Expect: The refreshed interface has the new MTU
+	// ------------------------------------------------------------------------
+
+	intf, err = splice.LinkSetMTU(intf, 1400)
+	if err != nil {
+		t.Fatal("LinkSetMTU Returned Error: ", err)
+	}
+	if intf.MTU != 1400 {
+		t.Fatal("LinkSetMTU Returned Unexpected MTU: ", intf.MTU)
+	}
+
+	// (3)	Set the MAC Address
+	//			Expect: The refreshed interface has the new MAC address
+	// ------------------------------------------------------------------------
+
+	hwAddr, _ := net.ParseMAC("02:00:5e:10:00:01")
+
+	intf, err = splice.LinkSetHardwareAddr(intf, hwAddr)
+	if err != nil {
+		t.Fatal("LinkSetHardwareAddr Returned Error: ", err)
+	}
+	if intf.HardwareAddr.String() != hwAddr.String() {
+		t.Fatal("LinkSetHardwareAddr Returned Unexpected Address: ", intf.HardwareAddr)
+	}
+
+	// (4)	Set the Alias and Transmit Queue Length
+	//			Expect: Both are reported by LinkGet
+	// ------------------------------------------------------------------------
+
+	if intf, err = splice.LinkSetAlias(intf, "uplink"); err != nil {
+		t.Fatal("LinkSetAlias Returned Error: ", err)
+	}
+	if intf, err = splice.LinkSetTxQueueLen(intf, 500); err != nil {
+		t.Fatal("LinkSetTxQueueLen Returned Error: ", err)
+	}
+
+	link, err := splice.LinkGet(intf)
+	if err != nil {
+		t.Fatal("LinkGet Returned Error: ", err)
+	}
+	if link.Alias != "uplink" || link.TxQueueLen != 500 {
+		t.Fatal("LinkGet Returned Unexpected Alias or Queue Length: ", link.Alias, link.TxQueueLen)
+	}
+}
+
+func TestLinkSetMTU_InvalidIntfValue(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Set the MTU of an Invalid Link
+	//			Expect: Error since the interface is invalid
+	// ------------------------------------------------------------------------
+
+	intf := &net.Interface{Index: -1}
+	if _, err := splice.LinkSetMTU(intf, 1400); err == nil {
+		t.Fatal("LinkSetMTU Did Not Return an Error with Invalid Interface value")
+	}
+}
+
+// ============================================================================
+//	LinkRename
+// ============================================================================
+
+func TestLinkRename(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Create a Bridge
+	// ------------------------------------------------------------------------
+
+	intf, err := splice.LinkCreateBridge(RandomIntfName("br"))
+	if err != nil {
+		t.Fatal("LinkCreateBridge Returned Error: ", err)
+	}
+
+	// (2)	Rename the Bridge
+	//			Expect: The refreshed interface has the new name
+	// ------------------------------------------------------------------------
+
+	name := RandomIntfName("br")
+
+	renamed, err := splice.LinkRename(intf, name)
+	if err != nil {
+		t.Fatal("LinkRename Returned Error: ", err)
+	}
+	if renamed.Name != name || renamed.Index != intf.Index {
+		t.Fatal("LinkRename Returned Unexpected Interface: ", renamed.Name, renamed.Index)
+	}
+	if IntfExists(intf.Name) {
+		t.Fatal("Link Still Exists Under its Old Name")
+	}
+}
+
+func TestLinkRename_Exists(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Create a Bridge
+	// ------------------------------------------------------------------------
+
+	intf, err := splice.LinkCreateBridge(RandomIntfName("br"))
+	if err != nil {
+		t.Fatal("LinkCreateBridge Returned Error: ", err)
+	}
+
+	// (2)	Rename the Bridge to the Loopback's Name
+	//			Expect: ErrExists
+	// ------------------------------------------------------------------------
+
+	if _, err := splice.LinkRename(intf, config.loopbackIntf.Name); !errors.Is(err, splice.ErrExists) {
+		t.Fatal("LinkRename Did Not Return ErrExists: ", err)
+	}
+}
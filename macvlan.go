@@ -0,0 +1,52 @@
+/*
+Copyright 2016 Arroyo Networks, LLC
+Copyright 2019 Project Contributors (See AUTHORS File).
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package splice
+
+import (
+	"strconv"
+)
+
+// The mode of a MACVLAN interface, controlling how traffic is forwarded
+// between MACVLAN interfaces sharing the same parent.
+type MACVLANMode int
+
+const (
+	MACVLANModePrivate  MACVLANMode = iota + 1 // No traffic between MACVLAN interfaces.
+	MACVLANModeVEPA                            // Traffic is hairpinned by the external switch.
+	MACVLANModeBridge                          // Traffic is bridged directly on the parent.
+	MACVLANModePassthru                        // A single MACVLAN interface takes over the parent.
+)
+
+var macvlanModeNames = map[MACVLANMode]string{
+	MACVLANModePrivate:  "private",
+	MACVLANModeVEPA:     "vepa",
+	MACVLANModeBridge:   "bridge",
+	MACVLANModePassthru: "passthru",
+}
+
+func (m MACVLANMode) String() string {
+	if name, ok := macvlanModeNames[m]; ok {
+		return name
+	}
+	return strconv.Itoa(int(m))
+}
+
+// Describes the configuration of a MACVLAN interface.
+type MACVLANInfo struct {
+	Mode MACVLANMode
+}
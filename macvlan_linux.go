@@ -0,0 +1,54 @@
+/*
+Copyright 2016 Arroyo Networks, LLC
+Copyright 2019 Project Contributors (See AUTHORS File).
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package splice
+
+import (
+	"errors"
+	"github.com/vishvananda/netlink"
+	"net"
+)
+
+// Provides MACVLAN interface management for Linux using netlink.
+
+// Returns the MACVLAN configuration of a netlink MACVLAN link.
+func macvlanFromNetlink(macvlan *netlink.Macvlan) *MACVLANInfo {
+	return &MACVLANInfo{
+		Mode: MACVLANMode(macvlan.Mode),
+	}
+}
+
+// Creates a MACVLAN interface on top of the given parent interface, with its
+// own MAC address. A mode of 0 uses the system default. The interface is
+// created administratively down.
+// This is equivalent to 'ip link add link <parent.Name> name <name> type
+// macvlan mode <mode>'.
+func LinkCreateMACVLAN(parent *net.Interface, name string, mode MACVLANMode) (*net.Interface, error) {
+
+	if _, ok := macvlanModeNames[mode]; !ok && mode != 0 {
+		return nil, errors.New("Unsupported MACVLAN mode")
+	}
+
+	attrs := netlink.NewLinkAttrs()
+	attrs.Name = name
+	attrs.ParentIndex = parent.Index
+
+	return linkAdd(&netlink.Macvlan{
+		LinkAttrs: attrs,
+		Mode:      netlink.MacvlanMode(mode),
+	})
+}
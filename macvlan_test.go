@@ -0,0 +1,78 @@
+/*
+Copyright 2016 Arroyo Networks, LLC
+Copyright 2019 Project Contributors (See AUTHORS File).
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package splice_test
+
+import (
+	"github.com/arroyonetworks/splice"
+	"testing"
+)
+
+// ============================================================================
+//	LinkCreateMACVLAN
+// ============================================================================
+
+func TestLinkCreateMACVLAN(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	parent := GetDummyUpIntf(t)
+
+	// (1)	Create a MACVLAN Interface in Bridge Mode
+	//			Expect: No error
+	// ------------------------------------------------------------------------
+
+	intf, err := splice.LinkCreateMACVLAN(parent, RandomIntfName("macvlan"), splice.MACVLANModeBridge)
+	SkipIfNotSupported(t, err)
+	if err != nil {
+		t.Fatal("LinkCreateMACVLAN Returned Error: ", err)
+	}
+
+	// (2)	Get the Link
+	//			Expect: A bridge mode MACVLAN on the parent is reported
+	// ------------------------------------------------------------------------
+
+	link, err := splice.LinkGet(intf)
+	if err != nil {
+		t.Fatal("LinkGet Returned Error: ", err)
+	}
+
+	if link.MACVLAN == nil || link.ParentIndex != parent.Index {
+		t.Fatal("Link Is Not a MACVLAN of the Parent: ", link.Kind, link.ParentIndex)
+	}
+	if link.MACVLAN.Mode != splice.MACVLANModeBridge {
+		t.Fatal("MACVLAN Has Unexpected Mode: ", link.MACVLAN.Mode)
+	}
+	if link.Interface.HardwareAddr.String() == parent.HardwareAddr.String() {
+		t.Fatal("MACVLAN Shares the Parent's MAC Address")
+	}
+}
+
+func TestLinkCreateMACVLAN_InvalidMode(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Create a MACVLAN Interface with an Unknown Mode
+	//			Expect: Error
+	// ------------------------------------------------------------------------
+
+	if _, err := splice.LinkCreateMACVLAN(config.loopbackIntf, RandomIntfName("macvlan"), 42); err == nil {
+		t.Fatal("LinkCreateMACVLAN Did Not Return an Error with Invalid Mode")
+	}
+}
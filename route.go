@@ -0,0 +1,215 @@
+/*
+Copyright 2016 Arroyo Networks, LLC
+Copyright 2019 Project Contributors (See AUTHORS File).
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package splice
+
+import (
+	"net"
+	"strconv"
+)
+
+// Well-known route protocols, identifying the originator of a route.
+// Values above RouteProtocolStatic are free for use by routing daemons.
+const (
+	RouteProtocolUnspec   = 0 // Unknown.
+	RouteProtocolRedirect = 1 // Installed by an ICMP redirect.
+	RouteProtocolKernel   = 2 // Installed by the kernel.
+	RouteProtocolBoot     = 3 // Installed during boot, the default.
+	RouteProtocolStatic   = 4 // Installed by the administrator.
+)
+
+// Optional parameters used when adding or replacing a route.
+// Fields left at their zero value use the system defaults.
+type RouteOptions struct {
+	Table     int            // Routing table to add the route to (default: main).
+	VRF       *net.Interface // VRF whose routing table the route is added to, overriding Table.
+	Metric    int            // Route priority, lower is preferred.
+	Source    net.IP         // Preferred source address for the route.
+	Interface *net.Interface // Output interface of a route via a gateway.
+	OnLink    bool           // Gateways are reachable on-link, even if not in a local subnet.
+	Protocol  int            // Originator of the route, used to identify owned routes.
+	MTU       int            // Path MTU towards the destination.
+	AdvMSS    int            // TCP maximum segment size advertised to the destination.
+	InitCwnd  int            // Initial TCP congestion window, in segments.
+	InitRwnd  int            // Initial TCP receive window, in segments.
+}
+
+// Optional parameters used to select which route is removed by RouteDelete.
+// Fields left at their zero value match any route.
+type RouteDeleteOptions struct {
+	Gateway   net.IP         // Only match routes via this gateway.
+	Interface *net.Interface // Only match routes out of this interface.
+	Table     int            // Routing table to delete from (default: main).
+	VRF       *net.Interface // VRF whose routing table is deleted from, overriding Table.
+	Type      RouteType      // Only match routes of this type.
+	Metric    int            // Only match routes with this metric.
+}
+
+// An IP address family.
+type Family int
+
+const (
+	FamilyIPv4 Family = 4
+	FamilyIPv6 Family = 6
+)
+
+func (f Family) String() string {
+	switch f {
+	case FamilyIPv4:
+		return "ipv4"
+	case FamilyIPv6:
+		return "ipv6"
+	}
+	return strconv.Itoa(int(f))
+}
+
+// The type of a route entry.
+// The values mirror the route types used by Linux's rtnetlink.
+type RouteType int
+
+const (
+	RouteTypeUnspecified RouteType = iota
+	RouteTypeUnicast               // Gateway or direct route.
+	RouteTypeLocal                 // Accept locally.
+	RouteTypeBroadcast             // Accept locally as broadcast, send as broadcast.
+	RouteTypeAnycast               // Accept locally as broadcast, send as unicast.
+	RouteTypeMulticast             // Multicast route.
+	RouteTypeBlackhole             // Drop silently.
+	RouteTypeUnreachable           // Destination is unreachable.
+	RouteTypeProhibit              // Administratively prohibited.
+	RouteTypeThrow                 // Not in this table, continue lookup.
+	RouteTypeNAT                   // Translate this address.
+)
+
+var routeTypeNames = map[RouteType]string{
+	RouteTypeUnspecified: "unspec",
+	RouteTypeUnicast:     "unicast",
+	RouteTypeLocal:       "local",
+	RouteTypeBroadcast:   "broadcast",
+	RouteTypeAnycast:     "anycast",
+	RouteTypeMulticast:   "multicast",
+	RouteTypeBlackhole:   "blackhole",
+	RouteTypeUnreachable: "unreachable",
+	RouteTypeProhibit:    "prohibit",
+	RouteTypeThrow:       "throw",
+	RouteTypeNAT:         "nat",
+}
+
+func (t RouteType) String() string {
+	if name, ok := routeTypeNames[t]; ok {
+		return name
+	}
+	return strconv.Itoa(int(t))
+}
+
+// The scope of a route entry, i.e. the distance to the destination.
+// The values mirror the route scopes used by Linux's rtnetlink.
+type RouteScope int
+
+const (
+	RouteScopeUniverse RouteScope = 0   // Global route.
+	RouteScopeSite     RouteScope = 200 // Interior route in the local system.
+	RouteScopeLink     RouteScope = 253 // Route on a directly attached link.
+	RouteScopeHost     RouteScope = 254 // Route on the local host.
+	RouteScopeNowhere  RouteScope = 255 // Destination does not exist.
+)
+
+var routeScopeNames = map[RouteScope]string{
+	RouteScopeUniverse: "global",
+	RouteScopeSite:     "site",
+	RouteScopeLink:     "link",
+	RouteScopeHost:     "host",
+	RouteScopeNowhere:  "nowhere",
+}
+
+func (s RouteScope) String() string {
+	if name, ok := routeScopeNames[s]; ok {
+		return name
+	}
+	return strconv.Itoa(int(s))
+}
+
+// One of the paths used by a multipath route.
+type NextHop struct {
+	Gateway   net.IP         // Next hop gateway, nil if directly connected.
+	Interface *net.Interface // Output interface, nil if not bound to one.
+	Weight    int            // Relative share of traffic, between 1 and 256.
+}
+
+// A single entry in the system's routing table.
+type Route struct {
+	Destination *net.IPNet     // Destination network.
+	Gateway     net.IP         // Next hop gateway, nil if directly connected.
+	Interface   *net.Interface // Output interface, nil if not bound to one.
+	Source      net.IP         // Preferred source address.
+	Metric      int            // Route priority, lower is preferred.
+	Table       int            // Routing table the route belongs to.
+	Protocol    int            // Originator of the route (e.g. kernel, boot, static).
+	Scope       RouteScope
+	Type        RouteType
+	NextHops    []*NextHop // Paths of a multipath route, nil otherwise.
+}
+
+// Selects routes returned by RouteList.
+// Fields left at their zero value match any route.
+type RouteFilter struct {
+	Destination *net.IPNet     // Only match routes to exactly this network.
+	Gateway     net.IP         // Only match routes via this gateway.
+	Interface   *net.Interface // Only match routes out of this interface.
+	Table       int            // Routing table to list (default: main, or RouteTableAll).
+	VRF         *net.Interface // VRF whose routing table is listed, overriding Table.
+	Protocol    int            // Only match routes from this protocol.
+	Type        RouteType      // Only match routes of this type.
+}
+
+// Optional parameters used to describe the traffic given to RouteLookup.
+// Fields left at their zero value are not used for the lookup.
+type RouteLookupOptions struct {
+	Source         net.IP         // Source address of the traffic.
+	InputInterface *net.Interface // Interface the traffic arrives on.
+	Mark           uint32         // Firewall mark of the traffic.
+	Table          int            // Only consult this routing table, RouteTableAll is not allowed.
+	VRF            *net.Interface // VRF the traffic originates from.
+}
+
+// The kind of change reported by a RouteEvent.
+type RouteEventType int
+
+const (
+	RouteEventAdd    RouteEventType = iota + 1 // A route was added.
+	RouteEventDelete                           // A route was removed.
+	RouteEventChange                           // An existing route was replaced.
+)
+
+var routeEventTypeNames = map[RouteEventType]string{
+	RouteEventAdd:    "add",
+	RouteEventDelete: "delete",
+	RouteEventChange: "change",
+}
+
+func (t RouteEventType) String() string {
+	if name, ok := routeEventTypeNames[t]; ok {
+		return name
+	}
+	return strconv.Itoa(int(t))
+}
+
+// A change to the system's routing table, delivered by RouteSubscribe.
+type RouteEvent struct {
+	Type  RouteEventType
+	Route *Route
+}
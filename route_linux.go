@@ -18,8 +18,12 @@ limitations under the License.
 package splice
 
 import (
+	"context"
+	"errors"
 	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
 	"net"
+	"sort"
 )
 
 // Provides route table manipulation for Linux using netlink.
@@ -39,41 +43,629 @@ func RouteExistsTo(destination net.IP) bool {
 // destination network.
 func RouteHasEntry(destination *net.IPNet) bool {
 
-	filter := &netlink.Route{
-		Dst: destination,
+	return RouteHasEntryFiltered(&RouteFilter{Destination: destination})
+}
+
+// Determines if any route matches the given filter. As with RouteList, only
+// the main table is checked unless the filter selects another table or VRF.
+func RouteHasEntryFiltered(filter *RouteFilter) bool {
+
+	routes, err := RouteList(filter)
+	return err == nil && len(routes) > 0
+}
+
+// Returns the netlink table and filter mask used to list routes from the
+// given table. The main table is used if none is given.
+func routeTableFilter(table int) (int, uint64) {
+
+	switch table {
+	case RouteTableUnspec:
+		return RouteTableUnspec, 0
+	case RouteTableAll:
+		return unix.RT_TABLE_UNSPEC, netlink.RT_FILTER_TABLE
+	default:
+		return table, netlink.RT_FILTER_TABLE
 	}
-	if routes, err := netlink.RouteListFiltered(netlink.FAMILY_ALL, filter, 0); err == nil {
-		for _, route := range routes {
-			if route.Dst != nil {
-				if route.Dst.String() == destination.String() {
-					return true
-				}
-			}
+}
+
+// Applies the optional route parameters to the netlink route.
+func applyRouteOptions(route *netlink.Route, opts *RouteOptions) error {
+
+	if opts == nil {
+		return nil
+	}
+
+	if opts.Table > 0 {
+		route.Table = opts.Table
+	}
+	if opts.VRF != nil {
+		table, err := vrfTable(opts.VRF)
+		if err != nil {
+			return err
 		}
+		route.Table = table
 	}
+	if opts.Metric > 0 {
+		route.Priority = opts.Metric
+	}
+	if opts.Source != nil {
+		route.Src = opts.Source
+	}
+	if opts.Interface != nil && route.LinkIndex == 0 && len(route.MultiPath) == 0 {
+		route.LinkIndex = opts.Interface.Index
+	}
+	if opts.OnLink {
+		route.SetFlag(netlink.FLAG_ONLINK)
+		for _, nh := range route.MultiPath {
+			nh.Flags |= int(netlink.FLAG_ONLINK)
+		}
+	}
+	if opts.Protocol > 0 {
+		route.Protocol = netlink.RouteProtocol(opts.Protocol)
+	}
+
+	route.MTU = opts.MTU
+	route.AdvMSS = opts.AdvMSS
+	route.InitCwnd = opts.InitCwnd
+	route.InitRwnd = opts.InitRwnd
 
-	return false
+	return nil
+}
+
+// Adds the route to the routing table, translating an existing entry into a
+// RouteExistsError.
+func routeAdd(route *netlink.Route) error {
+
+	err := netlink.RouteAdd(route)
+	if err == unix.EEXIST {
+		return &RouteExistsError{Destination: route.Dst}
+	}
+	return err
 }
 
 // Adds a new route to the given IP network, routed by the given gateway.
+// A RouteExistsError is returned if the route is already present.
 // This is equivalent to 'ip route add <destination> via <gateway>'.
 func RouteAddViaGateway(destination *net.IPNet, gateway net.IP) error {
 
+	return RouteAddViaGatewayWithOptions(destination, gateway, nil)
+}
+
+// Adds a new route to the given IP network, routed by the given gateway,
+// applying the given options. The options may be nil. A RouteExistsError is
+// returned if the route is already present.
+// This is equivalent to 'ip route add <destination> via <gateway> [table
+// <table>] [metric <metric>] ...'.
+func RouteAddViaGatewayWithOptions(destination *net.IPNet, gateway net.IP, opts *RouteOptions) error {
+
 	route := &netlink.Route{
 		Dst: destination,
 		Gw:  gateway,
 	}
-	return netlink.RouteAdd(route)
+	if err := applyRouteOptions(route, opts); err != nil {
+		return err
+	}
+
+	return routeAdd(route)
 }
 
 // Adds a new route to the given IP network, send out the given interface.
+// A RouteExistsError is returned if the route is already present.
 // This is equivalent to 'ip route add <destination> dev <intf.Name>'.
 func RouteAddViaInterface(destination *net.IPNet, intf *net.Interface) error {
 
+	return RouteAddViaInterfaceWithOptions(destination, intf, nil)
+}
+
+// Adds a new route to the given IP network, send out the given interface,
+// applying the given options. The options may be nil. A RouteExistsError is
+// returned if the route is already present.
+// This is equivalent to 'ip route add <destination> dev <intf.Name> [table
+// <table>] [metric <metric>] ...'.
+func RouteAddViaInterfaceWithOptions(destination *net.IPNet, intf *net.Interface, opts *RouteOptions) error {
+
 	route := &netlink.Route{
 		Dst:       destination,
 		LinkIndex: intf.Index,
 		Scope:     netlink.SCOPE_LINK,
 	}
-	return netlink.RouteAdd(route)
+	if err := applyRouteOptions(route, opts); err != nil {
+		return err
+	}
+
+	return routeAdd(route)
+}
+
+// Adds a new route to the given IP network, balanced across the given next
+// hops according to their weights. The options may be nil.
+// A RouteExistsError is returned if the route is already present.
+// This is equivalent to 'ip route add <destination> nexthop via <gateway>
+// dev <intf.Name> weight <weight> [nexthop ...]'.
+func RouteAddMultipath(destination *net.IPNet, nextHops []*NextHop, opts *RouteOptions) error {
+
+	if len(nextHops) == 0 {
+		return errors.New("No next hops given")
+	}
+
+	route := &netlink.Route{
+		Dst: destination,
+	}
+
+	for _, nh := range nextHops {
+		// The kernel stores the weight less one in a single byte.
+		if nh.Weight < 1 || nh.Weight > 256 {
+			return errors.New("Next hop weights must be between 1 and 256")
+		}
+		info := &netlink.NexthopInfo{
+			Gw: nh.Gateway,
+		}
+		if nh.Interface != nil {
+			info.LinkIndex = nh.Interface.Index
+		}
+		info.Hops = nh.Weight - 1
+		route.MultiPath = append(route.MultiPath, info)
+	}
+	if err := applyRouteOptions(route, opts); err != nil {
+		return err
+	}
+
+	return routeAdd(route)
+}
+
+// Adds a new route to the given IP network which does not forward traffic.
+// The route type must be one of RouteTypeBlackhole, RouteTypeUnreachable,
+// RouteTypeProhibit or RouteTypeThrow. The options may be nil. Such routes
+// can be removed with RouteDelete.
+// A RouteExistsError is returned if the route is already present.
+// This is equivalent to 'ip route add <type> <destination>'.
+func RouteAddSpecial(destination *net.IPNet, routeType RouteType, opts *RouteOptions) error {
+
+	switch routeType {
+	case RouteTypeBlackhole, RouteTypeUnreachable, RouteTypeProhibit, RouteTypeThrow:
+	default:
+		return errors.New("Route type " + routeType.String() + " is not a special route type")
+	}
+
+	route := &netlink.Route{
+		Dst:  destination,
+		Type: int(routeType),
+	}
+	if err := applyRouteOptions(route, opts); err != nil {
+		return err
+	}
+
+	return routeAdd(route)
+}
+
+// Adds or replaces the route to the given IP network, routed by the given
+// gateway. The options may be nil.
+// This is equivalent to 'ip route replace <destination> via <gateway>'.
+func RouteReplaceViaGateway(destination *net.IPNet, gateway net.IP, opts *RouteOptions) error {
+
+	route := &netlink.Route{
+		Dst: destination,
+		Gw:  gateway,
+	}
+	if err := applyRouteOptions(route, opts); err != nil {
+		return err
+	}
+
+	return netlink.RouteReplace(route)
+}
+
+// Adds or replaces the route to the given IP network, sent out the given
+// interface. The options may be nil.
+// This is equivalent to 'ip route replace <destination> dev <intf.Name>'.
+func RouteReplaceViaInterface(destination *net.IPNet, intf *net.Interface, opts *RouteOptions) error {
+
+	route := &netlink.Route{
+		Dst:       destination,
+		LinkIndex: intf.Index,
+		Scope:     netlink.SCOPE_LINK,
+	}
+	if err := applyRouteOptions(route, opts); err != nil {
+		return err
+	}
+
+	return netlink.RouteReplace(route)
+}
+
+// Removes the route to the given IP network from the routing table.
+// The options may be nil, otherwise they further narrow which route is
+// removed. A RouteNotFoundError is returned if no route matches.
+// This is equivalent to 'ip route del <destination> [via <gateway>]
+// [dev <intf.Name>] [table <table>]'.
+func RouteDelete(destination *net.IPNet, opts *RouteDeleteOptions) error {
+
+	if opts == nil {
+		opts = &RouteDeleteOptions{}
+	}
+
+	// A scope of 'nowhere' matches routes of any scope.
+	route := &netlink.Route{
+		Dst:      destination,
+		Gw:       opts.Gateway,
+		Table:    opts.Table,
+		Scope:    netlink.SCOPE_NOWHERE,
+		Type:     int(opts.Type),
+		Priority: opts.Metric,
+	}
+	if opts.Interface != nil {
+		route.LinkIndex = opts.Interface.Index
+	}
+	if opts.VRF != nil {
+		table, err := vrfTable(opts.VRF)
+		if err != nil {
+			return err
+		}
+		route.Table = table
+	}
+
+	// IPv4 reports a missing route as ESRCH, while IPv6 reports ENOENT.
+	err := netlink.RouteDel(route)
+	if err == unix.ESRCH || err == unix.ENOENT {
+		return &RouteNotFoundError{Destination: destination}
+	}
+	return err
+}
+
+// Resolves the interface with the given index. Lookups are memoized in the
+// given cache, which may be nil. If the interface can no longer be resolved,
+// an Interface with only the index populated is returned.
+func intfByIndex(index int, cache map[int]*net.Interface) *net.Interface {
+
+	if index <= 0 {
+		return nil
+	}
+	if intf, ok := cache[index]; ok {
+		return intf
+	}
+
+	intf, err := net.InterfaceByIndex(index)
+	if err != nil {
+		intf = &net.Interface{Index: index}
+	}
+	if cache != nil {
+		cache[index] = intf
+	}
+	return intf
+}
+
+// Returns the netlink address family of the given IP address.
+func ipFamily(ip net.IP) int {
+
+	if ip.To4() != nil {
+		return netlink.FAMILY_V4
+	}
+	return netlink.FAMILY_V6
+}
+
+// Returns the network containing only the given IP address.
+func hostNetwork(ip net.IP) *net.IPNet {
+
+	if ip4 := ip.To4(); ip4 != nil {
+		return &net.IPNet{IP: ip4, Mask: net.CIDRMask(32, 32)}
+	}
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(128, 128)}
+}
+
+// Returns the network matching every address of the given family.
+func defaultDestination(family int) *net.IPNet {
+
+	if family == netlink.FAMILY_V6 {
+		return &net.IPNet{IP: net.IPv6zero, Mask: net.CIDRMask(0, 128)}
+	}
+	return &net.IPNet{IP: net.IPv4zero.To4(), Mask: net.CIDRMask(0, 32)}
+}
+
+// Converts a netlink route into a splice Route.
+func routeFromNetlink(r *netlink.Route, intfs map[int]*net.Interface) *Route {
+
+	route := &Route{
+		Destination: r.Dst,
+		Gateway:     r.Gw,
+		Interface:   intfByIndex(r.LinkIndex, intfs),
+		Source:      r.Src,
+		Metric:      r.Priority,
+		Table:       r.Table,
+		Protocol:    int(r.Protocol),
+		Scope:       RouteScope(r.Scope),
+		Type:        RouteType(r.Type),
+	}
+
+	for _, nh := range r.MultiPath {
+		route.NextHops = append(route.NextHops, &NextHop{
+			Gateway:   nh.Gw,
+			Interface: intfByIndex(nh.LinkIndex, intfs),
+			Weight:    nh.Hops + 1,
+		})
+	}
+
+	// Netlink omits the destination of default routes.
+	if route.Destination == nil {
+		route.Destination = defaultDestination(r.Family)
+	}
+
+	return route
+}
+
+// Returns the routes in the routing table which match the given filter.
+// If the filter is nil, all routes in the main table are returned.
+// This is equivalent to 'ip route show [table <table>] [<destination>]'.
+func RouteList(filter *RouteFilter) ([]*Route, error) {
+
+	var routes []*Route
+	var mask uint64
+
+	nlFilter := &netlink.Route{}
+	families := []int{netlink.FAMILY_V4, netlink.FAMILY_V6}
+
+	if filter != nil {
+		table := filter.Table
+		if filter.VRF != nil {
+			vrf, err := vrfTable(filter.VRF)
+			if err != nil {
+				return nil, err
+			}
+			table = vrf
+		}
+		nlFilter.Table, mask = routeTableFilter(table)
+
+		if filter.Destination != nil {
+			nlFilter.Dst = filter.Destination
+			mask |= netlink.RT_FILTER_DST
+			families = []int{ipFamily(filter.Destination.IP)}
+		}
+		if filter.Gateway != nil {
+			nlFilter.Gw = filter.Gateway
+			mask |= netlink.RT_FILTER_GW
+		}
+		if filter.Interface != nil {
+			nlFilter.LinkIndex = filter.Interface.Index
+			mask |= netlink.RT_FILTER_OIF
+		}
+		if filter.Protocol != 0 {
+			nlFilter.Protocol = netlink.RouteProtocol(filter.Protocol)
+			mask |= netlink.RT_FILTER_PROTOCOL
+		}
+		if filter.Type != RouteTypeUnspecified {
+			nlFilter.Type = int(filter.Type)
+			mask |= netlink.RT_FILTER_TYPE
+		}
+	}
+
+	intfs := make(map[int]*net.Interface)
+
+	for _, family := range families {
+		nlRoutes, err := netlink.RouteListFiltered(family, nlFilter, mask)
+		if err != nil {
+			return nil, err
+		}
+		for i := range nlRoutes {
+			routes = append(routes, routeFromNetlink(&nlRoutes[i], intfs))
+		}
+	}
+
+	return routes, nil
+}
+
+// Returns the route the system selects for traffic to the given IP address.
+// The returned route describes the chosen path: its gateway, egress
+// interface, preferred source address and routing table. The options may be
+// nil. A RouteNotFoundError is returned if the destination is unreachable.
+//
+// If a table is given, the lookup is restricted to the routes in that table
+// and bypasses the routing policy rules. The remaining options are not used
+// in this case. Only unicast and local routes are returned by such a lookup:
+// if the best match is a throw, blackhole, unreachable or prohibit route, a
+// RouteNotFoundError is returned. RouteTableAll can not be used for lookups.
+//
+// If a VRF is given, the lookup is performed as if the traffic originated
+// from within the VRF.
+// This is equivalent to 'ip route get <destination> [from <source>]
+// [iif <intf.Name>] [mark <mark>] [vrf <vrf.Name>]'.
+func RouteLookup(destination net.IP, opts *RouteLookupOptions) (*Route, error) {
+
+	if opts == nil {
+		opts = &RouteLookupOptions{}
+	}
+
+	if opts.Table == RouteTableAll {
+		return nil, errors.New("Route lookups require a single routing table")
+	}
+	if opts.Table != 0 {
+		return routeLookupInTable(destination, opts.Table)
+	}
+
+	nlOpts := &netlink.RouteGetOptions{
+		SrcAddr: opts.Source,
+		Mark:    opts.Mark,
+	}
+	if opts.InputInterface != nil {
+		nlOpts.IifIndex = opts.InputInterface.Index
+	}
+	if opts.VRF != nil {
+		if _, err := vrfTable(opts.VRF); err != nil {
+			return nil, err
+		}
+		nlOpts.VrfName = opts.VRF.Name
+	}
+
+	// Blackhole, unreachable and prohibit routes are reported as EINVAL,
+	// EHOSTUNREACH and EACCES respectively.
+	nlRoutes, err := netlink.RouteGetWithOptions(destination, nlOpts)
+	switch {
+	case err == unix.ENETUNREACH, err == unix.EINVAL, err == unix.EHOSTUNREACH, err == unix.EACCES,
+		err == nil && len(nlRoutes) == 0:
+		return nil, &RouteNotFoundError{Destination: hostNetwork(destination)}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	route := routeFromNetlink(&nlRoutes[0], nil)
+
+	// The kernel omits the preferred source of output routes when it is the
+	// same as the requested source.
+	if route.Source == nil && opts.InputInterface == nil {
+		route.Source = opts.Source
+	}
+
+	return route, nil
+}
+
+// Returns the most specific route in the given table which contains the
+// destination, preferring the lowest metric among equally specific routes.
+// Matching routes which do not forward traffic, such as throw routes, end the
+// lookup without a path.
+func routeLookupInTable(destination net.IP, table int) (*Route, error) {
+
+	routes, err := RouteList(&RouteFilter{Table: table})
+	if err != nil {
+		return nil, err
+	}
+
+	var best *Route
+	bestLen := -1
+
+	for _, route := range routes {
+		if !route.Destination.Contains(destination) {
+			continue
+		}
+		prefixLen, _ := route.Destination.Mask.Size()
+		if prefixLen > bestLen || (prefixLen == bestLen && route.Metric < best.Metric) {
+			best = route
+			bestLen = prefixLen
+		}
+	}
+
+	if best == nil || (best.Type != RouteTypeUnicast && best.Type != RouteTypeLocal) {
+		return nil, &RouteNotFoundError{Destination: hostNetwork(destination)}
+	}
+	return best, nil
+}
+
+// Returns the netlink address family of the given family.
+func familyToNetlink(family Family) int {
+
+	if family == FamilyIPv6 {
+		return netlink.FAMILY_V6
+	}
+	return netlink.FAMILY_V4
+}
+
+// Returns every default route of the given address family in the main table,
+// ordered by preference (lowest metric first).
+// This is equivalent to 'ip [-6] route show default'.
+func DefaultRouteList(family Family) ([]*Route, error) {
+
+	filter := &RouteFilter{
+		Destination: defaultDestination(familyToNetlink(family)),
+	}
+
+	routes, err := RouteList(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(routes, func(i, j int) bool {
+		return routes[i].Metric < routes[j].Metric
+	})
+
+	return routes, nil
+}
+
+// Returns the preferred default route of the given address family, i.e. the
+// one with the lowest metric. A RouteNotFoundError is returned if there is
+// no default route.
+func DefaultRouteGet(family Family) (*Route, error) {
+
+	routes, err := DefaultRouteList(family)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(routes) == 0 {
+		return nil, &RouteNotFoundError{Destination: defaultDestination(familyToNetlink(family))}
+	}
+	return routes[0], nil
+}
+
+// Sets the default route of the given address family, replacing an existing
+// default route with the same metric. The gateway may be nil if an output
+// interface is given in the options. Additional default routes (e.g. for
+// several uplinks) can be installed by giving each a different metric.
+// This is equivalent to 'ip [-6] route replace default via <gateway>'.
+func DefaultRouteSet(family Family, gateway net.IP, opts *RouteOptions) error {
+
+	if gateway == nil && (opts == nil || opts.Interface == nil) {
+		return errors.New("Either a gateway or an interface must be given")
+	}
+	if gateway != nil && ipFamily(gateway) != familyToNetlink(family) {
+		return errors.New("Gateway is not an " + family.String() + " address")
+	}
+
+	route := &netlink.Route{
+		Dst: defaultDestination(familyToNetlink(family)),
+		Gw:  gateway,
+	}
+	if gateway == nil {
+		route.Scope = netlink.SCOPE_LINK
+	}
+	if err := applyRouteOptions(route, opts); err != nil {
+		return err
+	}
+
+	return netlink.RouteReplace(route)
+}
+
+// Removes a default route of the given address family. The options may be
+// nil, otherwise they narrow which default route is removed (e.g. by gateway
+// or metric). A RouteNotFoundError is returned if no default route matches.
+// This is equivalent to 'ip [-6] route del default'.
+func DefaultRouteDelete(family Family, opts *RouteDeleteOptions) error {
+
+	return RouteDelete(defaultDestination(familyToNetlink(family)), opts)
+}
+
+// Converts a netlink route update into a splice RouteEvent.
+func routeEventFromNetlink(update *netlink.RouteUpdate) RouteEvent {
+
+	event := RouteEvent{
+		Type:  RouteEventAdd,
+		Route: routeFromNetlink(&update.Route, nil),
+	}
+
+	switch {
+	case update.Type == unix.RTM_DELROUTE:
+		event.Type = RouteEventDelete
+	case update.NlFlags&unix.NLM_F_REPLACE != 0:
+		event.Type = RouteEventChange
+	}
+
+	return event
+}
+
+// Subscribes to changes of the IPv4 and IPv6 routing tables. Events are
+// delivered on the returned channel until the context is cancelled, after
+// which the channel is closed. The channel is also closed if the
+// subscription fails.
+// This is equivalent to 'ip monitor route'.
+func RouteSubscribe(ctx context.Context) (<-chan RouteEvent, error) {
+
+	updates := make(chan netlink.RouteUpdate)
+	done := make(chan struct{})
+
+	if err := netlink.RouteSubscribeWithOptions(updates, done, netlink.RouteSubscribeOptions{}); err != nil {
+		return nil, err
+	}
+
+	events := make(chan RouteEvent)
+
+	go subscriptionForward(ctx, updates, done, events, func(u interface{}) (interface{}, bool) {
+		update := u.(netlink.RouteUpdate)
+		return routeEventFromNetlink(&update), true
+	})
+
+	return events, nil
 }
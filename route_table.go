@@ -0,0 +1,188 @@
+/*
+Copyright 2016 Arroyo Networks, LLC
+Copyright 2019 Project Contributors (See AUTHORS File).
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package splice
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Provides routing table identifiers and name resolution using the iproute2
+// rt_tables database.
+
+// Well-known routing table identifiers.
+const (
+	RouteTableAll     = -1  // Matches every table when listing routes.
+	RouteTableUnspec  = 0   // Unspecified, routes use the main table.
+	RouteTableDefault = 253 // Default table, consulted after main.
+	RouteTableMain    = 254 // Main table, used when none is given.
+	RouteTableLocal   = 255 // Local and broadcast addresses.
+)
+
+var (
+	// Locations of the iproute2 routing table database, in order of
+	// preference. Entries in the 'rt_tables.d' directory beside the first
+	// file which exists are also loaded.
+	routeTablesPaths = []string{
+		"/etc/iproute2/rt_tables",
+		"/usr/share/iproute2/rt_tables",
+		"/usr/lib/iproute2/rt_tables",
+	}
+
+	// Tables known to iproute2 even without a database.
+	builtinRouteTables = map[string]int{
+		"unspec":  RouteTableUnspec,
+		"default": RouteTableDefault,
+		"main":    RouteTableMain,
+		"local":   RouteTableLocal,
+	}
+)
+
+// Parses an iproute2 rt_tables database, returning a mapping of table names
+// to their identifiers. Each line contains a decimal or hexadecimal table
+// identifier followed by its name, and '#' begins a comment.
+func ParseRouteTables(r io.Reader) (map[string]int, error) {
+
+	tables := make(map[string]int)
+	scanner := bufio.NewScanner(r)
+
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+
+		line := scanner.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("rt_tables: line %d: expected '<id> <name>'", lineNo)
+		}
+
+		id, err := strconv.ParseUint(fields[0], 0, 32)
+		if err != nil {
+			return nil, fmt.Errorf("rt_tables: line %d: invalid table id %q", lineNo, fields[0])
+		}
+
+		tables[fields[1]] = int(id)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return tables, nil
+}
+
+// Loads the system's routing table names, including the built-in tables.
+func loadRouteTables() (map[string]int, error) {
+
+	tables := make(map[string]int)
+	for name, id := range builtinRouteTables {
+		tables[name] = id
+	}
+
+	for _, path := range routeTablesPaths {
+
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+
+		extra, _ := filepath.Glob(path + ".d/*.conf")
+		sort.Strings(extra)
+
+		for _, file := range append([]string{path}, extra...) {
+			if err := loadRouteTablesFile(file, tables); err != nil {
+				return nil, err
+			}
+		}
+		break
+	}
+
+	return tables, nil
+}
+
+// Adds the tables named in the given rt_tables file to the mapping.
+func loadRouteTablesFile(path string, tables map[string]int) error {
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	parsed, err := ParseRouteTables(f)
+	if err != nil {
+		return fmt.Errorf("%s: %v", path, err)
+	}
+	for name, id := range parsed {
+		tables[name] = id
+	}
+	return nil
+}
+
+// Resolves a routing table name, such as 'main' or a name configured in
+// /etc/iproute2/rt_tables, into its identifier. Numeric names are returned
+// as-is. An error matching ErrNotFound is returned for unknown names.
+func RouteTableByName(name string) (int, error) {
+
+	if id, err := strconv.ParseUint(name, 0, 32); err == nil {
+		return int(id), nil
+	}
+
+	tables, err := loadRouteTables()
+	if err != nil {
+		return 0, err
+	}
+
+	if id, ok := tables[name]; ok {
+		return id, nil
+	}
+	return 0, fmt.Errorf("route table %q: %w", name, ErrNotFound)
+}
+
+// Returns the name of the given routing table identifier, as configured in
+// /etc/iproute2/rt_tables. Tables without a name are returned in decimal.
+func RouteTableName(id int) (string, error) {
+
+	tables, err := loadRouteTables()
+	if err != nil {
+		return "", err
+	}
+
+	// Prefer the lexically first name if a table has been given several.
+	found := ""
+	for name, tableID := range tables {
+		if tableID == id && (found == "" || name < found) {
+			found = name
+		}
+	}
+
+	if found == "" {
+		return strconv.Itoa(id), nil
+	}
+	return found, nil
+}
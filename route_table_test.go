@@ -0,0 +1,150 @@
+/*
+Copyright 2016 Arroyo Networks, LLC
+Copyright 2019 Project Contributors (See AUTHORS File).
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package splice_test
+
+import (
+	"errors"
+	"github.com/arroyonetworks/splice"
+	"os"
+	"testing"
+)
+
+// ============================================================================
+//	ParseRouteTables
+// ============================================================================
+
+func TestParseRouteTables(t *testing.T) {
+
+	// (1)	Parse the Fixture Database
+	//			Expect: No error
+	// ------------------------------------------------------------------------
+
+	f, err := os.Open("testdata/rt_tables")
+	if err != nil {
+		t.Fatal("Failed to Open Fixture: ", err)
+	}
+	defer f.Close()
+
+	tables, err := splice.ParseRouteTables(f)
+	if err != nil {
+		t.Fatal("ParseRouteTables Returned Error: ", err)
+	}
+
+	// (2)	Expect: Every table is present with its identifier
+	// ------------------------------------------------------------------------
+
+	expected := map[string]int{
+		"local":    255,
+		"main":     254,
+		"default":  253,
+		"unspec":   0,
+		"uplink_a": 100,
+		"uplink_b": 101,
+		"mgmt":     1000,
+	}
+
+	if len(tables) != len(expected) {
+		t.Error("ParseRouteTables Returned the Wrong Number of Tables: ", tables)
+	}
+	for name, id := range expected {
+		if got, ok := tables[name]; !ok || got != id {
+			t.Errorf("Table %s Parsed as %d, Expected %d", name, got, id)
+		}
+	}
+}
+
+func TestParseRouteTables_Corrupt(t *testing.T) {
+
+	// (1)	Parse the Corrupt Fixture Database
+	//			Expect: Error
+	// ------------------------------------------------------------------------
+
+	f, err := os.Open("testdata/rt_tables_corrupt")
+	if err != nil {
+		t.Fatal("Failed to Open Fixture: ", err)
+	}
+	defer f.Close()
+
+	if _, err := splice.ParseRouteTables(f); err == nil {
+		t.Fatal("ParseRouteTables Did Not Return an Error for a Corrupt Database")
+	}
+}
+
+// ============================================================================
+//	RouteTableByName
+// ============================================================================
+
+func TestRouteTableByName_Builtin(t *testing.T) {
+
+	// (1)	Resolve the Main Table
+	//			Expect: The main table identifier
+	// ------------------------------------------------------------------------
+
+	id, err := splice.RouteTableByName("main")
+	if err != nil {
+		t.Fatal("RouteTableByName Returned Error: ", err)
+	}
+	if id != splice.RouteTableMain {
+		t.Fatal("RouteTableByName Returned the Wrong Table: ", id)
+	}
+}
+
+func TestRouteTableByName_Numeric(t *testing.T) {
+
+	// (1)	Resolve a Numeric Table
+	//			Expect: The number itself
+	// ------------------------------------------------------------------------
+
+	id, err := splice.RouteTableByName("0x10")
+	if err != nil {
+		t.Fatal("RouteTableByName Returned Error: ", err)
+	}
+	if id != 16 {
+		t.Fatal("RouteTableByName Returned the Wrong Table: ", id)
+	}
+}
+
+func TestRouteTableByName_Unknown(t *testing.T) {
+
+	// (1)	Resolve a Table which is not Configured
+	//			Expect: ErrNotFound
+	// ------------------------------------------------------------------------
+
+	if _, err := splice.RouteTableByName("splice-missing-table"); !errors.Is(err, splice.ErrNotFound) {
+		t.Fatal("RouteTableByName Did Not Return a Not Found Error: ", err)
+	}
+}
+
+// ============================================================================
+//	RouteTableName
+// ============================================================================
+
+func TestRouteTableName(t *testing.T) {
+
+	// (1)	Name the Local Table
+	//			Expect: local
+	// ------------------------------------------------------------------------
+
+	name, err := splice.RouteTableName(splice.RouteTableLocal)
+	if err != nil {
+		t.Fatal("RouteTableName Returned Error: ", err)
+	}
+	if name != "local" {
+		t.Fatal("RouteTableName Returned the Wrong Name: ", name)
+	}
+}
@@ -18,9 +18,12 @@ limitations under the License.
 package splice_test
 
 import (
+	"context"
+	"errors"
 	"github.com/arroyonetworks/splice"
 	"net"
 	"testing"
+	"time"
 )
 
 // ============================================================================
@@ -136,6 +139,42 @@ func TestRouteHasEntry_SubnetRoute(t *testing.T) {
 	}
 }
 
+// ============================================================================
+//	RouteHasEntryFiltered
+// ============================================================================
+
+func TestRouteHasEntryFiltered_Table(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Stage a Route into an Alternate Table
+	// ------------------------------------------------------------------------
+
+	routeNet := RandomIPv4()
+	opts := &splice.RouteOptions{Table: 100}
+
+	if err := splice.RouteAddViaInterfaceWithOptions(routeNet, config.loopbackIntf, opts); err != nil {
+		t.Fatal("Failed to Stage Route: ", err)
+	}
+
+	// (2)	Test RouteHasEntryFiltered Against Each Table
+	//			Expected: true for the alternate table only
+	// ------------------------------------------------------------------------
+
+	filter := &splice.RouteFilter{Destination: routeNet, Table: 100}
+	if retval := splice.RouteHasEntryFiltered(filter); retval != true {
+		t.Error("RouteHasEntryFiltered Returned 'false' for a Route in the Alternate Table")
+	}
+	if retval := splice.RouteHasEntry(routeNet); retval != false {
+		t.Error("RouteHasEntry Returned 'true' for a Route Missing from the Main Table")
+	}
+	filter = &splice.RouteFilter{Destination: routeNet, Table: splice.RouteTableAll}
+	if retval := splice.RouteHasEntryFiltered(filter); retval != true {
+		t.Error("RouteHasEntryFiltered Returned 'false' for a Route in Any Table")
+	}
+}
+
 // ============================================================================
 //	RouteAddViaGateway
 // ============================================================================
@@ -189,6 +228,67 @@ func TestRouteAddViaGateway_UnreachableGW(t *testing.T) {
 	}
 }
 
+func TestRouteAddViaGateway_Exists(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Stage a Route via the Loopback Address
+	// ------------------------------------------------------------------------
+
+	routeNet := RandomIPv4()
+	gw := IPv4LoopbackAddr.IP
+
+	if err := splice.RouteAddViaGateway(routeNet, gw); err != nil {
+		t.Fatal("Failed to Stage Route: ", err)
+	}
+
+	// (2)	Add the Same Route Again
+	//			Expect: RouteExistsError
+	// ------------------------------------------------------------------------
+
+	err := splice.RouteAddViaGateway(routeNet, gw)
+	if _, ok := err.(*splice.RouteExistsError); !ok {
+		t.Fatal("RouteAddViaGateway Did Not Return a RouteExistsError: ", err)
+	}
+	if !errors.Is(err, splice.ErrExists) {
+		t.Fatal("RouteAddViaGateway Error Does Not Match ErrExists")
+	}
+}
+
+func TestRouteAddViaGateway_OnLink(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Add a Route via a Gateway Outside any Local Subnet
+	//			Expect: Error (Unreachable Network)
+	// ------------------------------------------------------------------------
+
+	intf := GetDummyUpIntf(t)
+
+	routeNet := RandomIPv4()
+	gw := net.ParseIP("192.0.2.1")
+
+	opts := &splice.RouteOptions{Interface: intf}
+	if err := splice.RouteAddViaGatewayWithOptions(routeNet, gw, opts); err == nil {
+		t.Fatal("No Error Returned for Unreachable Gateway")
+	}
+
+	// (2)	Add the Route with the Gateway On-Link
+	//			Expect: No error, and the route was added
+	// ------------------------------------------------------------------------
+
+	opts.OnLink = true
+	if err := splice.RouteAddViaGatewayWithOptions(routeNet, gw, opts); err != nil {
+		t.Fatal("RouteAddViaGatewayWithOptions Returned Error: ", err)
+	}
+
+	if !RouteExists(t, routeNet) {
+		t.Fatal("Added Route Does Not Exist in the Routing Table")
+	}
+}
+
 // ============================================================================
 //	RouteAddViaInterface
 // ============================================================================
@@ -215,3 +315,1199 @@ func TestRouteAddViaInterface(t *testing.T) {
 		t.Fatal("Added Route Does Not Exist in the Routing Table")
 	}
 }
+
+func TestRouteAddViaInterface_Exists(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Stage a Route into the Routing Table
+	// ------------------------------------------------------------------------
+
+	routeNet := RandomIPv4Route(t, config.loopbackIntf)
+
+	// (2)	Add the Same Route Again
+	//			Expect: ErrExists
+	// ------------------------------------------------------------------------
+
+	err := splice.RouteAddViaInterface(routeNet, config.loopbackIntf)
+	if !errors.Is(err, splice.ErrExists) {
+		t.Fatal("RouteAddViaInterface Did Not Return an Exists Error: ", err)
+	}
+}
+
+func TestRouteAddViaInterface_Options(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Add Route with Attributes via RouteAddViaInterface
+	//			Expect: No error
+	// ------------------------------------------------------------------------
+
+	routeNet := RandomIPv4()
+	opts := &splice.RouteOptions{
+		Metric:   50,
+		Source:   IPv4LoopbackAddr.IP,
+		Protocol: 200,
+		MTU:      1400,
+		AdvMSS:   1360,
+		InitCwnd: 10,
+		InitRwnd: 20,
+	}
+
+	if err := splice.RouteAddViaInterfaceWithOptions(routeNet, config.loopbackIntf, opts); err != nil {
+		t.Fatal("RouteAddViaInterfaceWithOptions Returned Error: ", err)
+	}
+
+	// (2)	Expect: The route is listed with its metric, source and protocol
+	// ------------------------------------------------------------------------
+
+	routes, err := splice.RouteList(&splice.RouteFilter{Protocol: 200})
+	if err != nil {
+		t.Fatal("RouteList Returned Error: ", err)
+	}
+	if len(routes) != 1 || routes[0].Destination.String() != routeNet.String() {
+		t.Fatal("Route Not Listed by its Protocol: ", routes)
+	}
+
+	if routes[0].Metric != 50 {
+		t.Error("Route Has the Wrong Metric: ", routes[0].Metric)
+	}
+	if !routes[0].Source.Equal(IPv4LoopbackAddr.IP) {
+		t.Error("Route Has the Wrong Source: ", routes[0].Source)
+	}
+}
+
+// ============================================================================
+//	RouteAddMultipath
+// ============================================================================
+
+func TestRouteAddMultipath(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Prepare two Uplinks
+	// ------------------------------------------------------------------------
+
+	intfA := GetDummyUpIntf(t)
+	_, gwA := RandomIPv4Neighbour(t, intfA)
+
+	intfB := GetDummyUpIntf(t)
+	_, gwB := RandomIPv4Neighbour(t, intfB)
+
+	// (2)	Add a Route Across Both Uplinks
+	//			Expect: No error
+	// ------------------------------------------------------------------------
+
+	routeNet := RandomIPv4()
+	nextHops := []*splice.NextHop{
+		{Gateway: gwA, Interface: intfA, Weight: 1},
+		{Gateway: gwB, Interface: intfB, Weight: 3},
+	}
+
+	if err := splice.RouteAddMultipath(routeNet, nextHops, nil); err != nil {
+		t.Fatal("RouteAddMultipath Returned Error: ", err)
+	}
+
+	// (3)	Expect: The route is listed with both weighted next hops
+	// ------------------------------------------------------------------------
+
+	routes, err := splice.RouteList(&splice.RouteFilter{Destination: routeNet})
+	if err != nil {
+		t.Fatal("RouteList Returned Error: ", err)
+	}
+	if len(routes) != 1 || len(routes[0].NextHops) != 2 {
+		t.Fatal("Multipath Route Not Listed with its Next Hops: ", routes)
+	}
+
+	for i, nh := range routes[0].NextHops {
+		if !nh.Gateway.Equal(nextHops[i].Gateway) {
+			t.Error("Next Hop Has the Wrong Gateway: ", nh.Gateway)
+		}
+		if nh.Interface == nil || nh.Interface.Name != nextHops[i].Interface.Name {
+			t.Error("Next Hop Has the Wrong Interface: ", nh.Interface)
+		}
+		if nh.Weight != nextHops[i].Weight {
+			t.Error("Next Hop Has the Wrong Weight: ", nh.Weight)
+		}
+	}
+}
+
+func TestRouteAddMultipath_NoNextHops(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Add a Multipath Route without Next Hops
+	//			Expect: Error
+	// ------------------------------------------------------------------------
+
+	routeNet := RandomIPv4()
+
+	if err := splice.RouteAddMultipath(routeNet, nil, nil); err == nil {
+		t.Fatal("RouteAddMultipath Did Not Return an Error Without Next Hops")
+	}
+
+	// (2)	Expect: The route was NOT added to the routing table
+	// ------------------------------------------------------------------------
+
+	if RouteExists(t, routeNet) {
+		t.Fatal("Route Exists in Routing Table when it Shouldn't")
+	}
+}
+
+func TestRouteAddMultipath_InvalidWeight(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	routeNet := RandomIPv4()
+
+	for _, weight := range []int{0, -1, 257} {
+
+		// (1)	Add a Multipath Route with an Out of Range Weight
+		//			Expect: Error
+		// --------------------------------------------------------------------
+
+		nextHops := []*splice.NextHop{
+			{Interface: config.loopbackIntf, Weight: weight},
+		}
+
+		if err := splice.RouteAddMultipath(routeNet, nextHops, nil); err == nil {
+			t.Fatal("RouteAddMultipath Did Not Return an Error with Weight: ", weight)
+		}
+	}
+
+	// (2)	Expect: The route was NOT added to the routing table
+	// ------------------------------------------------------------------------
+
+	if RouteExists(t, routeNet) {
+		t.Fatal("Route Exists in Routing Table when it Shouldn't")
+	}
+}
+
+// ============================================================================
+//	RouteAddSpecial
+// ============================================================================
+
+func TestRouteAddSpecial(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	types := []splice.RouteType{
+		splice.RouteTypeBlackhole,
+		splice.RouteTypeUnreachable,
+		splice.RouteTypeProhibit,
+		splice.RouteTypeThrow,
+	}
+
+	for _, routeType := range types {
+
+		// (1)	Add a Special Route
+		//			Expect: No error
+		// --------------------------------------------------------------------
+
+		routeNet := RandomIPv4()
+
+		if err := splice.RouteAddSpecial(routeNet, routeType, nil); err != nil {
+			t.Fatalf("RouteAddSpecial Returned Error for %s: %v", routeType, err)
+		}
+
+		// (2)	Expect: The route is listed with its type
+		// --------------------------------------------------------------------
+
+		routes, err := splice.RouteList(&splice.RouteFilter{Destination: routeNet})
+		if err != nil {
+			t.Fatal("RouteList Returned Error: ", err)
+		}
+		if len(routes) != 1 || routes[0].Type != routeType {
+			t.Fatalf("Special Route Not Listed as %s: %v", routeType, routes)
+		}
+	}
+}
+
+func TestRouteAddSpecial_IPv6(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Add an IPv6 Blackhole Route
+	//			Expect: No error
+	// ------------------------------------------------------------------------
+
+	_, routeNet, _ := net.ParseCIDR("2001:db8:5:5::/64")
+
+	if err := splice.RouteAddSpecial(routeNet, splice.RouteTypeBlackhole, nil); err != nil {
+		t.Fatal("RouteAddSpecial Returned Error: ", err)
+	}
+
+	// (2)	Expect: The route is listed as a blackhole
+	// ------------------------------------------------------------------------
+
+	routes, err := splice.RouteList(&splice.RouteFilter{Type: splice.RouteTypeBlackhole})
+	if err != nil {
+		t.Fatal("RouteList Returned Error: ", err)
+	}
+	if len(routes) != 1 || routes[0].Destination.String() != routeNet.String() {
+		t.Fatal("IPv6 Blackhole Route Not Listed: ", routes)
+	}
+}
+
+func TestRouteAddSpecial_InvalidType(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Add a Unicast Route as a Special Route
+	//			Expect: Error
+	// ------------------------------------------------------------------------
+
+	routeNet := RandomIPv4()
+
+	if err := splice.RouteAddSpecial(routeNet, splice.RouteTypeUnicast, nil); err == nil {
+		t.Fatal("RouteAddSpecial Did Not Return an Error for a Unicast Route")
+	}
+}
+
+// ============================================================================
+//	RouteReplaceViaGateway
+// ============================================================================
+
+func TestRouteReplaceViaGateway(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Stage a Route into the Routing Table
+	// ------------------------------------------------------------------------
+
+	routeNet := RandomIPv4Route(t, config.loopbackIntf)
+
+	// (2)	Replace the Route with one via the Loopback Address
+	//			Expect: No error
+	// ------------------------------------------------------------------------
+
+	if err := splice.RouteReplaceViaGateway(routeNet, IPv4LoopbackAddr.IP, nil); err != nil {
+		t.Fatal("RouteReplaceViaGateway Returned Error: ", err)
+	}
+
+	// (3)	Expect: The only route to the destination uses the gateway
+	// ------------------------------------------------------------------------
+
+	routes, err := splice.RouteList(&splice.RouteFilter{Destination: routeNet})
+	if err != nil {
+		t.Fatal("RouteList Returned Error: ", err)
+	}
+	if len(routes) != 1 || !routes[0].Gateway.Equal(IPv4LoopbackAddr.IP) {
+		t.Fatal("Route Was Not Replaced: ", routes)
+	}
+}
+
+// ============================================================================
+//	RouteReplaceViaInterface
+// ============================================================================
+
+func TestRouteReplaceViaInterface_MissingRoute(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Replace a Route which does not Exist
+	//			Expect: No error
+	// ------------------------------------------------------------------------
+
+	routeNet := RandomIPv4()
+
+	if err := splice.RouteReplaceViaInterface(routeNet, config.loopbackIntf, nil); err != nil {
+		t.Fatal("RouteReplaceViaInterface Returned Error: ", err)
+	}
+
+	// (2)	Expect: The route was added to the routing table
+	// ------------------------------------------------------------------------
+
+	if !RouteExists(t, routeNet) {
+		t.Fatal("Replaced Route Does Not Exist in the Routing Table")
+	}
+}
+
+func TestRouteReplaceViaInterface_Exists(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Stage a Route into the Routing Table
+	// ------------------------------------------------------------------------
+
+	routeNet := RandomIPv4Route(t, config.loopbackIntf)
+
+	// (2)	Replace the Same Route
+	//			Expect: No error
+	// ------------------------------------------------------------------------
+
+	if err := splice.RouteReplaceViaInterface(routeNet, config.loopbackIntf, nil); err != nil {
+		t.Fatal("RouteReplaceViaInterface Returned Error: ", err)
+	}
+}
+
+// ============================================================================
+//	RouteDelete
+// ============================================================================
+
+func TestRouteDelete(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Stage a Route into the Routing Table
+	// ------------------------------------------------------------------------
+
+	routeNet := RandomIPv4Route(t, config.loopbackIntf)
+
+	// (2)	Delete the Route
+	//			Expect: No error
+	// ------------------------------------------------------------------------
+
+	if err := splice.RouteDelete(routeNet, nil); err != nil {
+		t.Fatal("RouteDelete Returned Error: ", err)
+	}
+
+	// (3)	Expect: The route was removed from the routing table
+	// ------------------------------------------------------------------------
+
+	if RouteExists(t, routeNet) {
+		t.Fatal("Deleted Route Still Exists in the Routing Table")
+	}
+}
+
+func TestRouteDelete_MatchingInterface(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Stage a Route into the Routing Table
+	// ------------------------------------------------------------------------
+
+	routeNet := RandomIPv4Route(t, config.loopbackIntf)
+
+	// (2)	Delete the Route out of the Loopback Interface
+	//			Expect: No error
+	// ------------------------------------------------------------------------
+
+	opts := &splice.RouteDeleteOptions{Interface: config.loopbackIntf}
+	if err := splice.RouteDelete(routeNet, opts); err != nil {
+		t.Fatal("RouteDelete Returned Error: ", err)
+	}
+
+	// (3)	Expect: The route was removed from the routing table
+	// ------------------------------------------------------------------------
+
+	if RouteExists(t, routeNet) {
+		t.Fatal("Deleted Route Still Exists in the Routing Table")
+	}
+}
+
+func TestRouteDelete_MissingRoute(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Delete a Route which does not Exist
+	//			Expect: RouteNotFoundError
+	// ------------------------------------------------------------------------
+
+	routeNet := RandomIPv4()
+
+	err := splice.RouteDelete(routeNet, nil)
+	if _, ok := err.(*splice.RouteNotFoundError); !ok {
+		t.Fatal("RouteDelete Did Not Return a RouteNotFoundError: ", err)
+	}
+	if !errors.Is(err, splice.ErrNotFound) {
+		t.Fatal("RouteDelete Error Does Not Match ErrNotFound")
+	}
+}
+
+func TestRouteDelete_LinkScope(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Add a Link Scope Route via the Loopback Interface
+	// ------------------------------------------------------------------------
+
+	routeNet := RandomIPv4()
+
+	if err := splice.RouteAddViaInterface(routeNet, config.loopbackIntf); err != nil {
+		t.Fatal("RouteAddViaInterface Returned Error: ", err)
+	}
+
+	// (2)	Delete the Route
+	//			Expect: No error
+	// ------------------------------------------------------------------------
+
+	if err := splice.RouteDelete(routeNet, nil); err != nil {
+		t.Fatal("RouteDelete Returned Error: ", err)
+	}
+
+	// (3)	Expect: The route was removed from the routing table
+	// ------------------------------------------------------------------------
+
+	if RouteExists(t, routeNet) {
+		t.Fatal("Deleted Route Still Exists in the Routing Table")
+	}
+}
+
+func TestRouteDelete_MissingIPv6Route(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Delete an IPv6 Route which does not Exist
+	//			Expect: RouteNotFoundError
+	// ------------------------------------------------------------------------
+
+	_, routeNet, _ := net.ParseCIDR("2001:db8:5a1e::/64")
+
+	err := splice.RouteDelete(routeNet, nil)
+	if _, ok := err.(*splice.RouteNotFoundError); !ok {
+		t.Fatal("RouteDelete Did Not Return a RouteNotFoundError: ", err)
+	}
+	if !errors.Is(err, splice.ErrNotFound) {
+		t.Fatal("RouteDelete Error Does Not Match ErrNotFound")
+	}
+}
+
+func TestRouteDelete_MismatchedGateway(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Stage a Route via the Loopback Address
+	// ------------------------------------------------------------------------
+
+	routeNet := RandomIPv4()
+	if err := splice.RouteAddViaGateway(routeNet, IPv4LoopbackAddr.IP); err != nil {
+		t.Fatal("Failed to Stage Route: ", err)
+	}
+
+	// (2)	Delete the Route via a Different Gateway
+	//			Expect: RouteNotFoundError
+	// ------------------------------------------------------------------------
+
+	opts := &splice.RouteDeleteOptions{Gateway: net.ParseIP("127.0.0.2")}
+	if err := splice.RouteDelete(routeNet, opts); !errors.Is(err, splice.ErrNotFound) {
+		t.Fatal("RouteDelete Did Not Return a Not Found Error: ", err)
+	}
+
+	// (3)	Expect: The route is still in the routing table
+	// ------------------------------------------------------------------------
+
+	if !RouteExists(t, routeNet) {
+		t.Fatal("Route Was Removed Despite Mismatched Gateway")
+	}
+}
+
+func TestRouteDelete_Table(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Stage a Route into an Alternate Table
+	// ------------------------------------------------------------------------
+
+	routeNet := RandomIPv4()
+
+	err := splice.RouteAddViaInterfaceWithOptions(routeNet, config.loopbackIntf, &splice.RouteOptions{Table: 100})
+	if err != nil {
+		t.Fatal("Failed to Stage Route: ", err)
+	}
+
+	// (2)	Delete the Route from the Main Table
+	//			Expect: RouteNotFoundError
+	// ------------------------------------------------------------------------
+
+	if err := splice.RouteDelete(routeNet, nil); !errors.Is(err, splice.ErrNotFound) {
+		t.Fatal("RouteDelete Did Not Return a Not Found Error: ", err)
+	}
+
+	// (3)	Delete the Route from the Alternate Table
+	//			Expect: No error, and the route is gone
+	// ------------------------------------------------------------------------
+
+	if err := splice.RouteDelete(routeNet, &splice.RouteDeleteOptions{Table: 100}); err != nil {
+		t.Fatal("RouteDelete Returned Error: ", err)
+	}
+	if splice.RouteHasEntryFiltered(&splice.RouteFilter{Destination: routeNet, Table: 100}) {
+		t.Fatal("Deleted Route Still Exists in the Alternate Table")
+	}
+}
+
+func TestRouteDelete_Type(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Stage a Blackhole Route
+	// ------------------------------------------------------------------------
+
+	routeNet := RandomIPv4()
+
+	if err := splice.RouteAddSpecial(routeNet, splice.RouteTypeBlackhole, nil); err != nil {
+		t.Fatal("Failed to Stage Route: ", err)
+	}
+
+	// (2)	Delete an Unreachable Route to the Destination
+	//			Expect: RouteNotFoundError
+	// ------------------------------------------------------------------------
+
+	opts := &splice.RouteDeleteOptions{Type: splice.RouteTypeUnreachable}
+	if err := splice.RouteDelete(routeNet, opts); !errors.Is(err, splice.ErrNotFound) {
+		t.Fatal("RouteDelete Did Not Return a Not Found Error: ", err)
+	}
+
+	// (3)	Delete the Blackhole Route
+	//			Expect: No error, and the route is gone
+	// ------------------------------------------------------------------------
+
+	opts = &splice.RouteDeleteOptions{Type: splice.RouteTypeBlackhole}
+	if err := splice.RouteDelete(routeNet, opts); err != nil {
+		t.Fatal("RouteDelete Returned Error: ", err)
+	}
+	if RouteExists(t, routeNet) {
+		t.Fatal("Deleted Route Still Exists in the Routing Table")
+	}
+}
+
+// ============================================================================
+//	RouteList
+// ============================================================================
+
+func TestRouteList(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Stage a Route into the Routing Table
+	// ------------------------------------------------------------------------
+
+	routeNet := RandomIPv4Route(t, config.loopbackIntf)
+
+	// (2)	List all Routes
+	//			Expect: No error
+	// ------------------------------------------------------------------------
+
+	routes, err := splice.RouteList(nil)
+	if err != nil {
+		t.Fatal("RouteList Returned Error: ", err)
+	}
+
+	// (3)	Expect: The staged route is returned out the loopback interface
+	// ------------------------------------------------------------------------
+
+	found := false
+	for _, route := range routes {
+		if route.Destination.String() != routeNet.String() {
+			continue
+		}
+		found = true
+		if route.Interface == nil || route.Interface.Name != config.loopbackIntf.Name {
+			t.Error("Route Does Not Have the Loopback Interface: ", route.Interface)
+		}
+		if route.Type != splice.RouteTypeUnicast {
+			t.Error("Route Does Not Have the Unicast Type: ", route.Type)
+		}
+	}
+	if !found {
+		t.Fatal("Staged Route Not Returned")
+	}
+}
+
+func TestRouteList_DestinationFilter(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Stage two Routes into the Routing Table
+	// ------------------------------------------------------------------------
+
+	routeNet := RandomIPv4Route(t, config.loopbackIntf)
+	RandomIPv4Route(t, config.loopbackIntf)
+
+	// (2)	List Routes to the First Destination
+	//			Expect: Only the first route is returned
+	// ------------------------------------------------------------------------
+
+	routes, err := splice.RouteList(&splice.RouteFilter{Destination: routeNet})
+	if err != nil {
+		t.Fatal("RouteList Returned Error: ", err)
+	}
+
+	if len(routes) != 1 || routes[0].Destination.String() != routeNet.String() {
+		t.Fatal("RouteList Did Not Return Only the Filtered Route: ", routes)
+	}
+}
+
+func TestRouteList_GatewayFilter(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Stage a Route via the Loopback Address
+	// ------------------------------------------------------------------------
+
+	routeNet := RandomIPv4()
+	if err := splice.RouteAddViaGateway(routeNet, IPv4LoopbackAddr.IP); err != nil {
+		t.Fatal("Failed to Stage Route: ", err)
+	}
+
+	// (2)	List Routes via the Loopback Address
+	//			Expect: The staged route, with its gateway
+	// ------------------------------------------------------------------------
+
+	routes, err := splice.RouteList(&splice.RouteFilter{Gateway: IPv4LoopbackAddr.IP})
+	if err != nil {
+		t.Fatal("RouteList Returned Error: ", err)
+	}
+
+	if len(routes) != 1 || !routes[0].Gateway.Equal(IPv4LoopbackAddr.IP) {
+		t.Fatal("RouteList Did Not Return the Gateway Route: ", routes)
+	}
+}
+
+func TestRouteList_MissingRoute(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	List Routes to a Destination which does not Exist
+	//			Expect: No error and no routes
+	// ------------------------------------------------------------------------
+
+	routes, err := splice.RouteList(&splice.RouteFilter{Destination: RandomIPv4()})
+	if err != nil {
+		t.Fatal("RouteList Returned Error: ", err)
+	}
+	if len(routes) != 0 {
+		t.Fatal("RouteList Returned Routes for a Non-Existing Destination")
+	}
+}
+
+func TestRouteList_Table(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Stage a Route into the Main and an Alternate Table
+	// ------------------------------------------------------------------------
+
+	RandomIPv4Route(t, config.loopbackIntf)
+
+	routeNet := RandomIPv4()
+	opts := &splice.RouteOptions{Table: 100}
+
+	if err := splice.RouteAddViaInterfaceWithOptions(routeNet, config.loopbackIntf, opts); err != nil {
+		t.Fatal("Failed to Stage Route: ", err)
+	}
+
+	// (2)	List the Alternate Table
+	//			Expect: Only the alternate route is returned
+	// ------------------------------------------------------------------------
+
+	routes, err := splice.RouteList(&splice.RouteFilter{Table: 100})
+	if err != nil {
+		t.Fatal("RouteList Returned Error: ", err)
+	}
+	if len(routes) != 1 || routes[0].Destination.String() != routeNet.String() || routes[0].Table != 100 {
+		t.Fatal("RouteList Did Not Return Only the Alternate Table: ", routes)
+	}
+}
+
+// ============================================================================
+//	RouteLookup
+// ============================================================================
+
+func TestRouteLookup_ViaGateway(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Address a Dummy Interface and Stage a Route via a Neighbour
+	// ------------------------------------------------------------------------
+
+	intf := GetDummyUpIntf(t)
+	address, gw := RandomIPv4Neighbour(t, intf)
+
+	routeNet := RandomIPv4()
+	if err := splice.RouteAddViaGateway(routeNet, gw); err != nil {
+		t.Fatal("Failed to Stage Route: ", err)
+	}
+
+	// (2)	Lookup an Address within the Route
+	//			Expect: No error
+	// ------------------------------------------------------------------------
+
+	ip := net.IPv4(routeNet.IP[12], routeNet.IP[13], routeNet.IP[14], 10)
+
+	route, err := splice.RouteLookup(ip, nil)
+	if err != nil {
+		t.Fatal("RouteLookup Returned Error: ", err)
+	}
+
+	// (3)	Expect: The path uses the staged gateway in the main table
+	// ------------------------------------------------------------------------
+
+	if !route.Gateway.Equal(gw) {
+		t.Error("RouteLookup Returned the Wrong Gateway: ", route.Gateway)
+	}
+	if route.Interface == nil || route.Interface.Name != intf.Name {
+		t.Error("RouteLookup Returned the Wrong Interface: ", route.Interface)
+	}
+	if !route.Source.Equal(address.IP) {
+		t.Error("RouteLookup Returned the Wrong Source: ", route.Source)
+	}
+	if route.Table != 254 {
+		t.Error("RouteLookup Returned the Wrong Table: ", route.Table)
+	}
+}
+
+func TestRouteLookup_Source(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Stage a Route into the Routing Table
+	// ------------------------------------------------------------------------
+
+	routeNet := RandomIPv4Route(t, config.loopbackIntf)
+
+	// (2)	Lookup the Route from the Loopback Address
+	//			Expect: The loopback address is the preferred source
+	// ------------------------------------------------------------------------
+
+	opts := &splice.RouteLookupOptions{Source: IPv4LoopbackAddr.IP}
+
+	route, err := splice.RouteLookup(routeNet.IP, opts)
+	if err != nil {
+		t.Fatal("RouteLookup Returned Error: ", err)
+	}
+	if !route.Source.Equal(IPv4LoopbackAddr.IP) {
+		t.Error("RouteLookup Returned the Wrong Source: ", route.Source)
+	}
+}
+
+func TestRouteLookup_MissingRoute(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Lookup an Address without a Route
+	//			Expect: RouteNotFoundError
+	// ------------------------------------------------------------------------
+
+	ip := net.ParseIP("100.100.5.5")
+	if _, err := splice.RouteLookup(ip, nil); !errors.Is(err, splice.ErrNotFound) {
+		t.Fatal("RouteLookup Did Not Return a Not Found Error: ", err)
+	}
+}
+
+func TestRouteLookup_SpecialRoutes(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	for _, routeType := range []splice.RouteType{
+		splice.RouteTypeBlackhole,
+		splice.RouteTypeUnreachable,
+		splice.RouteTypeProhibit,
+	} {
+
+		// (1)	Stage a Route which does not Forward Traffic
+		// --------------------------------------------------------------------
+
+		routeNet := RandomIPv4()
+		if err := splice.RouteAddSpecial(routeNet, routeType, nil); err != nil {
+			t.Fatal("Failed to Stage Route: ", err)
+		}
+
+		// (2)	Lookup an Address within the Route
+		//			Expect: RouteNotFoundError
+		// --------------------------------------------------------------------
+
+		if _, err := splice.RouteLookup(routeNet.IP, nil); !errors.Is(err, splice.ErrNotFound) {
+			t.Fatal("RouteLookup Did Not Return a Not Found Error for a "+routeType.String()+" Route: ", err)
+		}
+	}
+}
+
+func TestRouteLookup_Table(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Stage a Default and a Specific Route into an Alternate Table
+	// ------------------------------------------------------------------------
+
+	opts := &splice.RouteOptions{Table: 100}
+
+	_, everything, _ := net.ParseCIDR("0.0.0.0/0")
+	if err := splice.RouteAddViaInterfaceWithOptions(everything, config.loopbackIntf, opts); err != nil {
+		t.Fatal("Failed to Stage Default Route: ", err)
+	}
+
+	routeNet := RandomIPv4()
+	if err := splice.RouteAddViaGatewayWithOptions(routeNet, IPv4LoopbackAddr.IP, opts); err != nil {
+		t.Fatal("Failed to Stage Route: ", err)
+	}
+
+	// (2)	Lookup an Address within the Specific Route
+	//			Expect: The most specific route is selected
+	// ------------------------------------------------------------------------
+
+	ip := net.IPv4(routeNet.IP[12], routeNet.IP[13], routeNet.IP[14], 10)
+
+	route, err := splice.RouteLookup(ip, &splice.RouteLookupOptions{Table: 100})
+	if err != nil {
+		t.Fatal("RouteLookup Returned Error: ", err)
+	}
+	if route.Destination.String() != routeNet.String() || !route.Gateway.Equal(IPv4LoopbackAddr.IP) {
+		t.Fatal("RouteLookup Did Not Select the Specific Route: ", route)
+	}
+
+	// (3)	Lookup an Address in the Main Table
+	//			Expect: RouteNotFoundError, the main table has no route
+	// ------------------------------------------------------------------------
+
+	if _, err := splice.RouteLookup(ip, nil); !errors.Is(err, splice.ErrNotFound) {
+		t.Fatal("RouteLookup Did Not Return a Not Found Error: ", err)
+	}
+}
+
+func TestRouteLookup_TableThrow(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Stage a Default and a More Specific Throw Route into a Table
+	// ------------------------------------------------------------------------
+
+	opts := &splice.RouteOptions{Table: 100}
+
+	_, everything, _ := net.ParseCIDR("0.0.0.0/0")
+	if err := splice.RouteAddViaInterfaceWithOptions(everything, config.loopbackIntf, opts); err != nil {
+		t.Fatal("Failed to Stage Default Route: ", err)
+	}
+
+	routeNet := RandomIPv4()
+	if err := splice.RouteAddSpecial(routeNet, splice.RouteTypeThrow, opts); err != nil {
+		t.Fatal("Failed to Stage Throw Route: ", err)
+	}
+
+	// (2)	Lookup an Address within the Throw Route
+	//			Expect: RouteNotFoundError, the throw route has no path
+	// ------------------------------------------------------------------------
+
+	ip := net.IPv4(routeNet.IP[12], routeNet.IP[13], routeNet.IP[14], 10)
+
+	if _, err := splice.RouteLookup(ip, &splice.RouteLookupOptions{Table: 100}); !errors.Is(err, splice.ErrNotFound) {
+		t.Fatal("RouteLookup Did Not Return a Not Found Error: ", err)
+	}
+}
+
+func TestRouteLookup_AllTables(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Lookup an Address in Every Table
+	//			Expect: Error, lookups require a single table
+	// ------------------------------------------------------------------------
+
+	opts := &splice.RouteLookupOptions{Table: splice.RouteTableAll}
+
+	if _, err := splice.RouteLookup(IPv4LoopbackAddr.IP, opts); err == nil {
+		t.Fatal("RouteLookup Did Not Return an Error with RouteTableAll")
+	}
+}
+
+// ============================================================================
+//	DefaultRouteSet
+// ============================================================================
+
+func TestDefaultRouteSet(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Set the IPv4 Default Route
+	//			Expect: No error
+	// ------------------------------------------------------------------------
+
+	if err := splice.DefaultRouteSet(splice.FamilyIPv4, IPv4LoopbackAddr.IP, nil); err != nil {
+		t.Fatal("DefaultRouteSet Returned Error: ", err)
+	}
+
+	// (2)	Set the IPv4 Default Route Again, out the Loopback Interface
+	//			Expect: No error, the existing route is replaced
+	// ------------------------------------------------------------------------
+
+	opts := &splice.RouteOptions{Interface: config.loopbackIntf}
+	if err := splice.DefaultRouteSet(splice.FamilyIPv4, nil, opts); err != nil {
+		t.Fatal("DefaultRouteSet Returned Error: ", err)
+	}
+
+	routes, err := splice.DefaultRouteList(splice.FamilyIPv4)
+	if err != nil {
+		t.Fatal("DefaultRouteList Returned Error: ", err)
+	}
+	if len(routes) != 1 || routes[0].Gateway != nil {
+		t.Fatal("Default Route Was Not Replaced: ", routes)
+	}
+}
+
+func TestDefaultRouteSet_IPv6(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Set the IPv6 Default Route out the Loopback Interface
+	//			Expect: No error
+	// ------------------------------------------------------------------------
+
+	opts := &splice.RouteOptions{Interface: config.loopbackIntf}
+	if err := splice.DefaultRouteSet(splice.FamilyIPv6, nil, opts); err != nil {
+		t.Fatal("DefaultRouteSet Returned Error: ", err)
+	}
+
+	// (2)	Expect: An IPv6 default route exists, and no IPv4 one
+	// ------------------------------------------------------------------------
+
+	route, err := splice.DefaultRouteGet(splice.FamilyIPv6)
+	if err != nil {
+		t.Fatal("DefaultRouteGet Returned Error: ", err)
+	}
+	if route.Destination.String() != "::/0" {
+		t.Fatal("DefaultRouteGet Returned the Wrong Destination: ", route.Destination)
+	}
+
+	if _, err := splice.DefaultRouteGet(splice.FamilyIPv4); !errors.Is(err, splice.ErrNotFound) {
+		t.Fatal("DefaultRouteGet Did Not Return a Not Found Error: ", err)
+	}
+}
+
+func TestDefaultRouteSet_MismatchedFamily(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Set the IPv6 Default Route via an IPv4 Gateway
+	//			Expect: Error
+	// ------------------------------------------------------------------------
+
+	if err := splice.DefaultRouteSet(splice.FamilyIPv6, IPv4LoopbackAddr.IP, nil); err == nil {
+		t.Fatal("DefaultRouteSet Did Not Return an Error for an IPv4 Gateway")
+	}
+}
+
+// ============================================================================
+//	DefaultRouteList
+// ============================================================================
+
+func TestDefaultRouteList_Metrics(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Set two IPv4 Default Routes with Different Metrics
+	// ------------------------------------------------------------------------
+
+	backup := &splice.RouteOptions{Interface: config.loopbackIntf, Metric: 200}
+	if err := splice.DefaultRouteSet(splice.FamilyIPv4, nil, backup); err != nil {
+		t.Fatal("Failed to Stage Backup Default Route: ", err)
+	}
+
+	primary := &splice.RouteOptions{Metric: 100}
+	if err := splice.DefaultRouteSet(splice.FamilyIPv4, IPv4LoopbackAddr.IP, primary); err != nil {
+		t.Fatal("Failed to Stage Primary Default Route: ", err)
+	}
+
+	// (2)	List the Default Routes
+	//			Expect: Both routes, lowest metric first
+	// ------------------------------------------------------------------------
+
+	routes, err := splice.DefaultRouteList(splice.FamilyIPv4)
+	if err != nil {
+		t.Fatal("DefaultRouteList Returned Error: ", err)
+	}
+	if len(routes) != 2 {
+		t.Fatal("DefaultRouteList Did Not Return Both Routes: ", routes)
+	}
+	if routes[0].Metric != 100 || !routes[0].Gateway.Equal(IPv4LoopbackAddr.IP) {
+		t.Error("Primary Default Route Not Listed First: ", routes[0])
+	}
+	if routes[1].Metric != 200 {
+		t.Error("Backup Default Route Not Listed Second: ", routes[1])
+	}
+
+	// (3)	Get the Default Route
+	//			Expect: The primary route
+	// ------------------------------------------------------------------------
+
+	route, err := splice.DefaultRouteGet(splice.FamilyIPv4)
+	if err != nil {
+		t.Fatal("DefaultRouteGet Returned Error: ", err)
+	}
+	if route.Metric != 100 {
+		t.Fatal("DefaultRouteGet Did Not Return the Primary Route: ", route)
+	}
+}
+
+// ============================================================================
+//	DefaultRouteDelete
+// ============================================================================
+
+func TestDefaultRouteDelete(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Set two IPv4 Default Routes with Different Metrics
+	// ------------------------------------------------------------------------
+
+	for _, metric := range []int{100, 200} {
+		opts := &splice.RouteOptions{Interface: config.loopbackIntf, Metric: metric}
+		if err := splice.DefaultRouteSet(splice.FamilyIPv4, nil, opts); err != nil {
+			t.Fatal("Failed to Stage Default Route: ", err)
+		}
+	}
+
+	// (2)	Delete the Default Route with the Higher Metric
+	//			Expect: No error, and only the other route remains
+	// ------------------------------------------------------------------------
+
+	opts := &splice.RouteDeleteOptions{Metric: 200}
+	if err := splice.DefaultRouteDelete(splice.FamilyIPv4, opts); err != nil {
+		t.Fatal("DefaultRouteDelete Returned Error: ", err)
+	}
+
+	routes, err := splice.DefaultRouteList(splice.FamilyIPv4)
+	if err != nil {
+		t.Fatal("DefaultRouteList Returned Error: ", err)
+	}
+	if len(routes) != 1 || routes[0].Metric != 100 {
+		t.Fatal("The Wrong Default Route Was Deleted: ", routes)
+	}
+}
+
+func TestDefaultRouteDelete_MissingRoute(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Delete a Default Route which does not Exist
+	//			Expect: RouteNotFoundError
+	// ------------------------------------------------------------------------
+
+	if err := splice.DefaultRouteDelete(splice.FamilyIPv6, nil); !errors.Is(err, splice.ErrNotFound) {
+		t.Fatal("DefaultRouteDelete Did Not Return a Not Found Error: ", err)
+	}
+}
+
+// ============================================================================
+//	RouteSubscribe
+// ============================================================================
+
+// Waits for an event of the given type for the given destination, ignoring
+// any unrelated events received in the meantime.
+func waitRouteEvent(t *testing.T, events <-chan splice.RouteEvent, eventType splice.RouteEventType, destination *net.IPNet) *splice.RouteEvent {
+
+	timeout := time.After(5 * time.Second)
+
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				t.Fatal("Route Event Channel Closed Unexpectedly")
+			}
+			if event.Type == eventType && event.Route.Destination.String() == destination.String() {
+				return &event
+			}
+		case <-timeout:
+			t.Fatalf("Timed Out Waiting for Route Event (%s %s)", eventType, destination)
+		}
+	}
+}
+
+func TestRouteSubscribe(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// (1)	Subscribe to Route Events
+	//			Expect: No error
+	// ------------------------------------------------------------------------
+
+	events, err := splice.RouteSubscribe(ctx)
+	if err != nil {
+		t.Fatal("RouteSubscribe Returned Error: ", err)
+	}
+
+	// (2)	Add a Route via RouteAddViaInterface
+	//			Expect: An add event for the route on the loopback interface
+	// ------------------------------------------------------------------------
+
+	routeNet := RandomIPv4()
+
+	if err := splice.RouteAddViaInterface(routeNet, config.loopbackIntf); err != nil {
+		t.Fatal("RouteAddViaInterface Returned Error: ", err)
+	}
+
+	event := waitRouteEvent(t, events, splice.RouteEventAdd, routeNet)
+	if event.Route.Interface == nil || event.Route.Interface.Index != config.loopbackIntf.Index {
+		t.Fatal("Add Event Has Unexpected Interface: ", event.Route.Interface)
+	}
+
+	// (3)	Replace the Route
+	//			Expect: A change event for the route
+	// ------------------------------------------------------------------------
+
+	opts := &splice.RouteOptions{MTU: 1400}
+
+	if err := splice.RouteReplaceViaInterface(routeNet, config.loopbackIntf, opts); err != nil {
+		t.Fatal("RouteReplaceViaInterface Returned Error: ", err)
+	}
+
+	waitRouteEvent(t, events, splice.RouteEventChange, routeNet)
+
+	// (4)	Delete the Route
+	//			Expect: A delete event for the route
+	// ------------------------------------------------------------------------
+
+	if err := splice.RouteDelete(routeNet, nil); err != nil {
+		t.Fatal("RouteDelete Returned Error: ", err)
+	}
+
+	waitRouteEvent(t, events, splice.RouteEventDelete, routeNet)
+
+	// (5)	Cancel the Context
+	//			Expect: The event channel is closed
+	// ------------------------------------------------------------------------
+
+	cancel()
+
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case _, ok := <-events:
+			if !ok {
+				return
+			}
+		case <-timeout:
+			t.Fatal("Route Event Channel Was Not Closed After Cancel")
+		}
+	}
+}
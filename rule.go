@@ -0,0 +1,91 @@
+/*
+Copyright 2016 Arroyo Networks, LLC
+Copyright 2019 Project Contributors (See AUTHORS File).
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package splice
+
+import (
+	"net"
+	"strconv"
+)
+
+// The action taken when a routing policy rule matches.
+type RuleAction int
+
+const (
+	RuleActionLookup      RuleAction = iota // Lookup the route in a table.
+	RuleActionGoto                          // Continue evaluation at another rule.
+	RuleActionBlackhole                     // Drop silently.
+	RuleActionUnreachable                   // Reject as unreachable.
+	RuleActionProhibit                      // Reject as administratively prohibited.
+	RuleActionNop                           // Do nothing, continue with the next rule.
+	RuleActionUnknown                       // An action not known to splice, only reported by RuleList.
+)
+
+var ruleActionNames = map[RuleAction]string{
+	RuleActionLookup:      "lookup",
+	RuleActionGoto:        "goto",
+	RuleActionBlackhole:   "blackhole",
+	RuleActionUnreachable: "unreachable",
+	RuleActionProhibit:    "prohibit",
+	RuleActionNop:         "nop",
+	RuleActionUnknown:     "unknown",
+}
+
+func (a RuleAction) String() string {
+	if name, ok := ruleActionNames[a]; ok {
+		return name
+	}
+	return strconv.Itoa(int(a))
+}
+
+// An inclusive range of transport layer ports.
+type PortRange struct {
+	Start uint16
+	End   uint16
+}
+
+// An inclusive range of user IDs.
+type UIDRange struct {
+	Start uint32
+	End   uint32
+}
+
+// A routing policy rule, selecting which routing table is consulted for
+// traffic. Selector fields left at their zero value match all traffic.
+type Rule struct {
+	Priority int  // Evaluation order, lower first. The system chooses if 0.
+	IPv6     bool // Applies to IPv6 traffic. Implied by From or To.
+	Invert   bool // Take the action when the selectors do NOT match.
+
+	// Selectors
+	From            *net.IPNet // Source network.
+	To              *net.IPNet // Destination network.
+	InputInterface  string     // Name of the interface traffic arrives on.
+	OutputInterface string     // Name of the interface traffic leaves from.
+	Mark            uint32     // Firewall mark.
+	Mask            uint32     // Bits of the firewall mark compared, all if 0.
+	TOS             uint8      // Type of service.
+	UIDRange        *UIDRange  // Owner of the originating socket.
+	IPProto         int        // IP protocol number (e.g. 6 for TCP).
+	SourcePort      *PortRange // Transport layer source port.
+	DestinationPort *PortRange // Transport layer destination port.
+
+	// Action
+	Action RuleAction
+	Table  int // Table looked up by RuleActionLookup, main if 0 when adding.
+	Goto   int // Priority of the rule jumped to by RuleActionGoto.
+}
@@ -0,0 +1,239 @@
+/*
+Copyright 2016 Arroyo Networks, LLC
+Copyright 2019 Project Contributors (See AUTHORS File).
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package splice
+
+import (
+	"errors"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netlink/nl"
+	"golang.org/x/sys/unix"
+	"net"
+)
+
+// Provides routing policy rule manipulation for Linux using netlink.
+
+// Maps rule actions to their fib rule action value.
+var ruleActionValues = map[RuleAction]uint8{
+	RuleActionLookup:      nl.FR_ACT_TO_TBL,
+	RuleActionGoto:        nl.FR_ACT_GOTO,
+	RuleActionBlackhole:   nl.FR_ACT_BLACKHOLE,
+	RuleActionUnreachable: nl.FR_ACT_UNREACHABLE,
+	RuleActionProhibit:    nl.FR_ACT_PROHIBIT,
+	RuleActionNop:         nl.FR_ACT_NOP,
+}
+
+// Converts a splice Rule into a netlink rule.
+func ruleToNetlink(rule *Rule) (*netlink.Rule, error) {
+
+	if _, ok := ruleActionValues[rule.Action]; !ok {
+		return nil, errors.New("Unsupported rule action " + rule.Action.String())
+	}
+
+	r := netlink.NewRule()
+
+	r.Family = netlink.FAMILY_V4
+	if rule.IPv6 {
+		r.Family = netlink.FAMILY_V6
+	}
+	if rule.From != nil {
+		r.Family = ipFamily(rule.From.IP)
+	}
+	if rule.To != nil {
+		r.Family = ipFamily(rule.To.IP)
+	}
+
+	if rule.Priority > 0 {
+		r.Priority = rule.Priority
+	}
+	r.Invert = rule.Invert
+
+	r.Src = rule.From
+	r.Dst = rule.To
+	r.IifName = rule.InputInterface
+	r.OifName = rule.OutputInterface
+	r.Mark = rule.Mark
+	if rule.Mask != 0 {
+		mask := rule.Mask
+		r.Mask = &mask
+	}
+	r.Tos = uint(rule.TOS)
+	r.IPProto = rule.IPProto
+	if rule.UIDRange != nil {
+		r.UIDRange = netlink.NewRuleUIDRange(rule.UIDRange.Start, rule.UIDRange.End)
+	}
+	if rule.SourcePort != nil {
+		r.Sport = netlink.NewRulePortRange(rule.SourcePort.Start, rule.SourcePort.End)
+	}
+	if rule.DestinationPort != nil {
+		r.Dport = netlink.NewRulePortRange(rule.DestinationPort.Start, rule.DestinationPort.End)
+	}
+
+	switch rule.Action {
+	case RuleActionLookup:
+		r.Table = rule.Table
+	case RuleActionGoto:
+		r.Goto = rule.Goto
+	default:
+		r.Type = ruleActionValues[rule.Action]
+	}
+
+	return r, nil
+}
+
+// Decodes a netlink rule message into a splice Rule.
+func ruleFromMessage(m []byte) (*Rule, error) {
+
+	msg := nl.DeserializeRtMsg(m)
+	attrs, err := nl.ParseRouteAttr(m[msg.Len():])
+	if err != nil {
+		return nil, err
+	}
+
+	native := nl.NativeEndian()
+
+	rule := &Rule{
+		IPv6:   msg.Family == unix.AF_INET6,
+		Invert: msg.Flags&netlink.FibRuleInvert != 0,
+		TOS:    msg.Tos,
+		Table:  int(msg.Table),
+	}
+
+	rule.Action = RuleActionUnknown
+	for action, value := range ruleActionValues {
+		if msg.Type == value {
+			rule.Action = action
+		}
+	}
+
+	for _, attr := range attrs {
+		switch attr.Attr.Type {
+		case nl.FRA_PRIORITY:
+			rule.Priority = int(native.Uint32(attr.Value[0:4]))
+		case nl.FRA_SRC:
+			rule.From = &net.IPNet{
+				IP:   net.IP(attr.Value),
+				Mask: net.CIDRMask(int(msg.Src_len), 8*len(attr.Value)),
+			}
+		case nl.FRA_DST:
+			rule.To = &net.IPNet{
+				IP:   net.IP(attr.Value),
+				Mask: net.CIDRMask(int(msg.Dst_len), 8*len(attr.Value)),
+			}
+		case nl.FRA_IIFNAME:
+			rule.InputInterface = string(attr.Value[:len(attr.Value)-1])
+		case nl.FRA_OIFNAME:
+			rule.OutputInterface = string(attr.Value[:len(attr.Value)-1])
+		case nl.FRA_FWMARK:
+			rule.Mark = native.Uint32(attr.Value[0:4])
+		case nl.FRA_FWMASK:
+			rule.Mask = native.Uint32(attr.Value[0:4])
+		case nl.FRA_UID_RANGE:
+			rule.UIDRange = &UIDRange{
+				Start: native.Uint32(attr.Value[0:4]),
+				End:   native.Uint32(attr.Value[4:8]),
+			}
+		case nl.FRA_IP_PROTO:
+			rule.IPProto = int(attr.Value[0])
+		case nl.FRA_SPORT_RANGE:
+			rule.SourcePort = &PortRange{
+				Start: native.Uint16(attr.Value[0:2]),
+				End:   native.Uint16(attr.Value[2:4]),
+			}
+		case nl.FRA_DPORT_RANGE:
+			rule.DestinationPort = &PortRange{
+				Start: native.Uint16(attr.Value[0:2]),
+				End:   native.Uint16(attr.Value[2:4]),
+			}
+		case nl.FRA_TABLE:
+			rule.Table = int(native.Uint32(attr.Value[0:4]))
+		case nl.FRA_GOTO:
+			rule.Goto = int(native.Uint32(attr.Value[0:4]))
+		}
+	}
+
+	return rule, nil
+}
+
+// Returns the routing policy rules of both address families, in order of
+// evaluation for each family.
+// This is equivalent to 'ip rule show; ip -6 rule show'.
+func RuleList() ([]*Rule, error) {
+
+	var rules []*Rule
+
+	for _, family := range []int{netlink.FAMILY_V4, netlink.FAMILY_V6} {
+
+		req := nl.NewNetlinkRequest(unix.RTM_GETRULE, unix.NLM_F_DUMP)
+		req.AddData(nl.NewIfInfomsg(family))
+
+		msgs, err := req.Execute(unix.NETLINK_ROUTE, unix.RTM_NEWRULE)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, m := range msgs {
+			rule, err := ruleFromMessage(m)
+			if err != nil {
+				return nil, err
+			}
+			rules = append(rules, rule)
+		}
+	}
+
+	return rules, nil
+}
+
+// Adds a routing policy rule. A lookup rule without a table looks up the
+// main table. ErrExists is returned if an identical rule is already present.
+// This is equivalent to 'ip rule add <selectors> <action>'.
+func RuleAdd(rule *Rule) error {
+
+	r, err := ruleToNetlink(rule)
+	if err != nil {
+		return err
+	}
+
+	// The kernel would otherwise create a new, empty table for the rule.
+	if rule.Action == RuleActionLookup && rule.Table == 0 {
+		r.Table = RouteTableMain
+	}
+
+	err = netlink.RuleAdd(r)
+	if err == unix.EEXIST {
+		return ErrExists
+	}
+	return err
+}
+
+// Removes the routing policy rule matching the given rule. Selectors left
+// at their zero value are not compared. ErrNotFound is returned if no rule
+// matches.
+// This is equivalent to 'ip rule del <selectors> <action>'.
+func RuleDelete(rule *Rule) error {
+
+	r, err := ruleToNetlink(rule)
+	if err != nil {
+		return err
+	}
+
+	err = netlink.RuleDel(r)
+	if err == unix.ENOENT {
+		return ErrNotFound
+	}
+	return err
+}
@@ -0,0 +1,337 @@
+/*
+Copyright 2016 Arroyo Networks, LLC
+Copyright 2019 Project Contributors (See AUTHORS File).
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package splice_test
+
+import (
+	"errors"
+	"github.com/arroyonetworks/splice"
+	"testing"
+)
+
+// Returns the listed rule with the given priority, or nil if there is none.
+func findRule(t *testing.T, priority int) *splice.Rule {
+
+	rules, err := splice.RuleList()
+	if err != nil {
+		t.Fatal("RuleList Returned Error: ", err)
+	}
+
+	for _, rule := range rules {
+		if rule.Priority == priority {
+			return rule
+		}
+	}
+	return nil
+}
+
+// ============================================================================
+//	RuleList
+// ============================================================================
+
+func TestRuleList(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	List the Rules of a Fresh Namespace
+	//			Expect: The default rule looking up the main table
+	// ------------------------------------------------------------------------
+
+	rule := findRule(t, 32766)
+	if rule == nil {
+		t.Fatal("Default Main Table Rule Not Returned")
+	}
+	if rule.Action != splice.RuleActionLookup || rule.Table != splice.RouteTableMain {
+		t.Fatal("Default Rule Does Not Lookup the Main Table: ", rule)
+	}
+}
+
+// ============================================================================
+//	RuleAdd
+// ============================================================================
+
+func TestRuleAdd_Lookup(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Add a Rule with Several Selectors
+	//			Expect: No error
+	// ------------------------------------------------------------------------
+
+	from := RandomIPv4()
+	rule := &splice.Rule{
+		Priority:        1000,
+		From:            from,
+		InputInterface:  "lo",
+		Mark:            0x10,
+		Mask:            0xff,
+		IPProto:         6,
+		DestinationPort: &splice.PortRange{Start: 80, End: 443},
+		UIDRange:        &splice.UIDRange{Start: 1000, End: 2000},
+		Action:          splice.RuleActionLookup,
+		Table:           100,
+	}
+
+	if err := splice.RuleAdd(rule); err != nil {
+		t.Fatal("RuleAdd Returned Error: ", err)
+	}
+
+	// (2)	Expect: The rule is listed with its selectors and action
+	// ------------------------------------------------------------------------
+
+	listed := findRule(t, 1000)
+	if listed == nil {
+		t.Fatal("Added Rule Not Returned")
+	}
+
+	if listed.From == nil || listed.From.String() != from.String() {
+		t.Error("Rule Has the Wrong Source: ", listed.From)
+	}
+	if listed.InputInterface != "lo" {
+		t.Error("Rule Has the Wrong Input Interface: ", listed.InputInterface)
+	}
+	if listed.Mark != 0x10 || listed.Mask != 0xff {
+		t.Errorf("Rule Has the Wrong Mark: %#x/%#x", listed.Mark, listed.Mask)
+	}
+	if listed.IPProto != 6 {
+		t.Error("Rule Has the Wrong IP Protocol: ", listed.IPProto)
+	}
+	if listed.DestinationPort == nil || *listed.DestinationPort != *rule.DestinationPort {
+		t.Error("Rule Has the Wrong Destination Ports: ", listed.DestinationPort)
+	}
+	if listed.UIDRange == nil || *listed.UIDRange != *rule.UIDRange {
+		t.Error("Rule Has the Wrong UID Range: ", listed.UIDRange)
+	}
+	if listed.Action != splice.RuleActionLookup || listed.Table != 100 {
+		t.Error("Rule Has the Wrong Action: ", listed.Action, listed.Table)
+	}
+}
+
+func TestRuleAdd_DefaultTable(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Add a Lookup Rule Without a Table
+	//			Expect: No error
+	// ------------------------------------------------------------------------
+
+	rule := &splice.Rule{
+		Priority: 1000,
+		From:     RandomIPv4(),
+		Action:   splice.RuleActionLookup,
+	}
+
+	if err := splice.RuleAdd(rule); err != nil {
+		t.Fatal("RuleAdd Returned Error: ", err)
+	}
+
+	// (2)	Expect: The rule looks up the main table
+	// ------------------------------------------------------------------------
+
+	listed := findRule(t, 1000)
+	if listed == nil {
+		t.Fatal("Added Rule Not Returned")
+	}
+	if listed.Action != splice.RuleActionLookup || listed.Table != splice.RouteTableMain {
+		t.Error("Rule Does Not Lookup the Main Table: ", listed.Action, listed.Table)
+	}
+}
+
+func TestRuleAdd_Blackhole(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Add a Blackhole Rule
+	//			Expect: No error
+	// ------------------------------------------------------------------------
+
+	rule := &splice.Rule{
+		Priority: 1000,
+		To:       RandomIPv4(),
+		Action:   splice.RuleActionBlackhole,
+	}
+
+	if err := splice.RuleAdd(rule); err != nil {
+		t.Fatal("RuleAdd Returned Error: ", err)
+	}
+
+	// (2)	Expect: The rule is listed as a blackhole
+	// ------------------------------------------------------------------------
+
+	listed := findRule(t, 1000)
+	if listed == nil || listed.Action != splice.RuleActionBlackhole {
+		t.Fatal("Blackhole Rule Not Returned: ", listed)
+	}
+}
+
+func TestRuleAdd_Goto(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Add a Rule Jumping to the Main Table Rule
+	//			Expect: No error
+	// ------------------------------------------------------------------------
+
+	rule := &splice.Rule{
+		Priority: 1000,
+		IPv6:     true,
+		Action:   splice.RuleActionGoto,
+		Goto:     32766,
+	}
+
+	if err := splice.RuleAdd(rule); err != nil {
+		t.Fatal("RuleAdd Returned Error: ", err)
+	}
+
+	// (2)	Expect: The rule is listed as an IPv6 goto
+	// ------------------------------------------------------------------------
+
+	listed := findRule(t, 1000)
+	if listed == nil || !listed.IPv6 || listed.Action != splice.RuleActionGoto || listed.Goto != 32766 {
+		t.Fatal("Goto Rule Not Returned: ", listed)
+	}
+}
+
+func TestRuleAdd_Nop(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Add a Rule Which Does Nothing
+	//			Expect: No error
+	// ------------------------------------------------------------------------
+
+	rule := &splice.Rule{
+		Priority: 1000,
+		Action:   splice.RuleActionNop,
+	}
+
+	if err := splice.RuleAdd(rule); err != nil {
+		t.Fatal("RuleAdd Returned Error: ", err)
+	}
+
+	// (2)	Expect: The rule is listed as a nop, not as a lookup
+	// ------------------------------------------------------------------------
+
+	listed := findRule(t, 1000)
+	if listed == nil || listed.Action != splice.RuleActionNop {
+		t.Fatal("Nop Rule Not Returned: ", listed)
+	}
+}
+
+func TestRuleAdd_UnknownAction(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Add a Rule with an Unknown Action
+	//			Expect: Error
+	// ------------------------------------------------------------------------
+
+	rule := &splice.Rule{
+		Priority: 1000,
+		Action:   splice.RuleActionUnknown,
+	}
+
+	if err := splice.RuleAdd(rule); err == nil {
+		t.Fatal("RuleAdd Did Not Return an Error with an Unknown Action")
+	}
+}
+
+func TestRuleAdd_Exists(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Add the Same Rule Twice
+	//			Expect: ErrExists
+	// ------------------------------------------------------------------------
+
+	rule := &splice.Rule{
+		Priority: 1000,
+		Mark:     0x20,
+		Action:   splice.RuleActionLookup,
+		Table:    100,
+	}
+
+	if err := splice.RuleAdd(rule); err != nil {
+		t.Fatal("Failed to Stage Rule: ", err)
+	}
+	if err := splice.RuleAdd(rule); !errors.Is(err, splice.ErrExists) {
+		t.Fatal("RuleAdd Did Not Return an Exists Error: ", err)
+	}
+}
+
+// ============================================================================
+//	RuleDelete
+// ============================================================================
+
+func TestRuleDelete(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Stage a Rule
+	// ------------------------------------------------------------------------
+
+	rule := &splice.Rule{
+		Priority: 1000,
+		From:     RandomIPv4(),
+		Action:   splice.RuleActionLookup,
+		Table:    100,
+	}
+
+	if err := splice.RuleAdd(rule); err != nil {
+		t.Fatal("Failed to Stage Rule: ", err)
+	}
+
+	// (2)	Delete the Rule
+	//			Expect: No error, and the rule is no longer listed
+	// ------------------------------------------------------------------------
+
+	if err := splice.RuleDelete(rule); err != nil {
+		t.Fatal("RuleDelete Returned Error: ", err)
+	}
+	if findRule(t, 1000) != nil {
+		t.Fatal("Deleted Rule Still Returned")
+	}
+}
+
+func TestRuleDelete_MissingRule(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Delete a Rule which does not Exist
+	//			Expect: ErrNotFound
+	// ------------------------------------------------------------------------
+
+	rule := &splice.Rule{
+		Priority: 1000,
+		Action:   splice.RuleActionLookup,
+		Table:    100,
+	}
+
+	if err := splice.RuleDelete(rule); !errors.Is(err, splice.ErrNotFound) {
+		t.Fatal("RuleDelete Did Not Return a Not Found Error: ", err)
+	}
+}
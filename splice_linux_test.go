@@ -5,12 +5,15 @@
 package splice_test
 
 import (
+	"errors"
 	"fmt"
 	"github.com/vishvananda/netlink"
 	"github.com/vishvananda/netns"
+	"golang.org/x/sys/unix"
 	"math/rand"
 	"net"
 	"os"
+	"runtime"
 	"testing"
 )
 
@@ -28,22 +31,65 @@ func init() {
 // Sets up a new Linux Test.
 // In Linux, we are able to create a new Network Namespace to run tests
 // in a fresh network stack, and prevent any interruptions to the host.
+// Namespaces are per-thread, so the test is locked to its OS thread until
+// the original namespace is restored on tear down.
 func _platformSetup(t *testing.T) func() {
 
 	if os.Getuid() != 0 {
 		SkipWithReason(t, "Test Setup Failed: Root Privileges are Required")
 	}
 
+	runtime.LockOSThread()
+
+	origin, err := netns.Get()
+	if err != nil {
+		runtime.UnlockOSThread()
+		SkipWithReason(t, "Test Setup Failed: Failed to Get Network Namespace: "+err.Error())
+	}
+
 	ns, err := netns.New()
 	if err != nil {
+		origin.Close()
+		runtime.UnlockOSThread()
 		SkipWithReason(t, "Test Setup Failed: Failed to Created Network Namespace: "+err.Error())
 	}
 
 	return func() {
+		netns.Set(origin)
+		origin.Close()
 		ns.Close()
+		runtime.UnlockOSThread()
 	}
 }
 
+// Runs the given function in a new goroutine within the test's network
+// namespace. The goroutine's thread is locked and discarded once done, as
+// it no longer belongs to the original namespace.
+func _platformGo(fn func()) error {
+
+	ns, err := netns.Get()
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		defer ns.Close()
+
+		runtime.LockOSThread()
+		if err := netns.Set(ns); err != nil {
+			return
+		}
+		fn()
+	}()
+
+	return nil
+}
+
+// Determines if an error was caused by a missing kernel feature.
+func _platformIsNotSupported(err error) bool {
+	return errors.Is(err, unix.EOPNOTSUPP)
+}
+
 // Sets up the Linux Loopback Adapter.
 func _platformSetupLoopback(t *testing.T) *net.Interface {
 
@@ -19,6 +19,8 @@ limitations under the License.
 package splice_test
 
 import (
+	"fmt"
+	"github.com/arroyonetworks/splice"
 	"log"
 	"math/rand"
 	"net"
@@ -74,6 +76,16 @@ func IntfExists(name string) bool {
 	return true
 }
 
+// Returns a random interface name with the given prefix which is not in use.
+func RandomIntfName(prefix string) string {
+	for {
+		name := fmt.Sprintf("%s%d", prefix, rand.Intn(127))
+		if !IntfExists(name) {
+			return name
+		}
+	}
+}
+
 func IntfIsDown(intf *net.Interface) bool {
 	return intf.Flags&net.FlagUp == 0
 }
@@ -91,6 +103,22 @@ func SkipWithReason(t *testing.T, reason string) {
 	t.Skip(reason)
 }
 
+// Runs the given function in a new goroutine, which shares the test's
+// network configuration.
+func GoInTest(t *testing.T, fn func()) {
+	if err := _platformGo(fn); err != nil {
+		t.Fatal("Failed to Start Goroutine: ", err)
+	}
+}
+
+// Skips the test if the error shows the platform does not support the
+// requested feature, such as a link type missing from the kernel.
+func SkipIfNotSupported(t *testing.T, err error) {
+	if err != nil && _platformIsNotSupported(err) {
+		SkipWithReason(t, "Not Supported by the Platform: "+err.Error())
+	}
+}
+
 // Adds a random route to the given interface.
 func RandomIPv4Route(t *testing.T, intf *net.Interface) *net.IPNet {
 	routeNet, err := _platformRandomIPv4Route(intf)
@@ -100,6 +128,23 @@ func RandomIPv4Route(t *testing.T, intf *net.Interface) *net.IPNet {
 	return routeNet
 }
 
+// Configures a random /24 IPv4 address on the given interface. Returns the
+// configured address along with a neighbouring address on the same network,
+// which can be used as a gateway.
+func RandomIPv4Neighbour(t *testing.T, intf *net.Interface) (*net.IPNet, net.IP) {
+	network := RandomIPv4()
+
+	address := &net.IPNet{
+		IP:   net.IPv4(network.IP[12], network.IP[13], network.IP[14], 1).To4(),
+		Mask: network.Mask,
+	}
+	if err := splice.AddressAdd(intf, address); err != nil {
+		t.Fatal("Failed to Add Random Address: ", err)
+	}
+
+	return address, net.IPv4(network.IP[12], network.IP[13], network.IP[14], 2)
+}
+
 // Determines if an interface has the given IP address configured.
 func IntfHasAddress(t *testing.T, intf *net.Interface, address *net.IPNet) bool {
 	return _platformIntfHasAddress(intf, address)
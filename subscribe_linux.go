@@ -0,0 +1,79 @@
+/*
+Copyright 2016 Arroyo Networks, LLC
+Copyright 2019 Project Contributors (See AUTHORS File).
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package splice
+
+import (
+	"context"
+	"reflect"
+)
+
+// Provides the event loop shared by the netlink subscriptions.
+
+// Stops a netlink subscription, and drains any update which is pending until
+// netlink closes the updates channel.
+func subscriptionStop(updates interface{}, done chan struct{}) {
+
+	close(done)
+
+	updatesValue := reflect.ValueOf(updates)
+	for {
+		if _, ok := updatesValue.Recv(); !ok {
+			return
+		}
+	}
+}
+
+// Forwards the updates of a netlink subscription to the events channel until
+// the context is done or netlink closes the updates channel, then stops the
+// subscription and closes the events channel. Each update is passed to
+// convert, which returns its event and whether the event should be sent.
+// The updates and events must be channels; they are not typed here as the
+// subscriptions each use their own update and event types.
+func subscriptionForward(ctx context.Context, updates interface{}, done chan struct{}, events interface{}, convert func(update interface{}) (interface{}, bool)) {
+
+	eventsValue := reflect.ValueOf(events)
+	ctxDone := reflect.ValueOf(ctx.Done())
+
+	defer eventsValue.Close()
+	defer subscriptionStop(updates, done)
+
+	receive := []reflect.SelectCase{
+		{Dir: reflect.SelectRecv, Chan: ctxDone},
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(updates)},
+	}
+
+	for {
+		chosen, update, ok := reflect.Select(receive)
+		if chosen == 0 || !ok {
+			return
+		}
+
+		event, send := convert(update.Interface())
+		if !send {
+			continue
+		}
+
+		chosen, _, _ = reflect.Select([]reflect.SelectCase{
+			{Dir: reflect.SelectRecv, Chan: ctxDone},
+			{Dir: reflect.SelectSend, Chan: eventsValue, Send: reflect.ValueOf(event)},
+		})
+		if chosen == 0 {
+			return
+		}
+	}
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2016 Arroyo Networks, LLC
+Copyright 2019 Project Contributors (See AUTHORS File).
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package splice
+
+import (
+	"net"
+	"strconv"
+)
+
+// The kind of an IP tunnel interface.
+type TunnelKind int
+
+const (
+	TunnelGRE    TunnelKind = iota + 1 // GRE carrying IP packets, over IPv4 or IPv6.
+	TunnelGRETap                       // GRE carrying ethernet frames, over IPv4 or IPv6.
+	TunnelIPIP                         // IPv4 in IPv4.
+	TunnelSIT                          // IPv6 in IPv4.
+	TunnelIP6Tnl                       // IPv4 or IPv6 in IPv6.
+)
+
+var tunnelKindNames = map[TunnelKind]string{
+	TunnelGRE:    "gre",
+	TunnelGRETap: "gretap",
+	TunnelIPIP:   "ipip",
+	TunnelSIT:    "sit",
+	TunnelIP6Tnl: "ip6tnl",
+}
+
+func (k TunnelKind) String() string {
+	if name, ok := tunnelKindNames[k]; ok {
+		return name
+	}
+	return strconv.Itoa(int(k))
+}
+
+// The UDP encapsulation applied to tunnelled packets.
+// The values mirror the TUNNEL_ENCAP_* types used by Linux.
+type TunnelEncap int
+
+const (
+	TunnelEncapNone TunnelEncap = 0
+	TunnelEncapFOU  TunnelEncap = 1 // Foo-over-UDP.
+	TunnelEncapGUE  TunnelEncap = 2 // Generic UDP Encapsulation.
+)
+
+func (e TunnelEncap) String() string {
+	switch e {
+	case TunnelEncapNone:
+		return "none"
+	case TunnelEncapFOU:
+		return "fou"
+	case TunnelEncapGUE:
+		return "gue"
+	}
+	return strconv.Itoa(int(e))
+}
+
+// Optional parameters used when creating a tunnel interface.
+// Fields left at their zero value use the system defaults. The underlay
+// family is taken from the local and remote addresses, which must match.
+type TunnelOptions struct {
+	Local      net.IP         // Source address of encapsulated packets.
+	Remote     net.IP         // Destination address of encapsulated packets.
+	Underlay   *net.Interface // Device used to send encapsulated packets.
+	Key        uint32         // GRE key, for both directions.
+	TTL        int            // TTL of encapsulated packets, 0 to inherit.
+	TOS        int            // TOS of encapsulated packets.
+	NoPMTUDisc bool           // Disable path MTU discovery on the tunnel.
+
+	// UDP encapsulation of tunnelled packets. The receiving side needs a
+	// matching FOU or GUE port, added with 'ip fou add'.
+	Encap                TunnelEncap
+	EncapSourcePort      int // 0 selects the source port automatically.
+	EncapDestinationPort int
+	EncapChecksum        bool // Calculate UDP checksums of encapsulated packets.
+}
+
+// Describes the configuration of a tunnel interface.
+type TunnelInfo struct {
+	Kind                 TunnelKind
+	Local                net.IP
+	Remote               net.IP
+	UnderlayIndex        int
+	Key                  uint32
+	TTL                  int
+	TOS                  int
+	PMTUDisc             bool
+	Encap                TunnelEncap
+	EncapSourcePort      int
+	EncapDestinationPort int
+	EncapChecksum        bool
+}
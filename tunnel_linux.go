@@ -0,0 +1,264 @@
+/*
+Copyright 2016 Arroyo Networks, LLC
+Copyright 2019 Project Contributors (See AUTHORS File).
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package splice
+
+import (
+	"errors"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+	"net"
+)
+
+// Provides IP tunnel interface management for Linux using netlink.
+
+// Flags of UDP encapsulated tunnels.
+const tunnelEncapFlagCsum = 1 << 0
+
+// Default IPv6 tunnel encapsulation limit, as used by iproute2.
+const ip6TunnelDefaultEncapLimit = 4
+
+// Returns an unspecified address of the same family as ip.
+func unspecifiedLike(ip net.IP) net.IP {
+	if ip.To4() != nil {
+		return net.IPv4zero
+	}
+	return net.IPv6unspecified
+}
+
+// Sets the UDP encapsulation parameters of a tunnel configuration.
+func tunnelEncapInfo(info *TunnelInfo, encapType, encapFlags, sport, dport uint16) *TunnelInfo {
+	info.Encap = TunnelEncap(encapType)
+	info.EncapSourcePort = int(sport)
+	info.EncapDestinationPort = int(dport)
+	info.EncapChecksum = encapFlags&tunnelEncapFlagCsum != 0
+	return info
+}
+
+// Returns the tunnel configuration of a netlink tunnel link, or nil if the
+// link is not a tunnel.
+func tunnelFromNetlink(link netlink.Link) *TunnelInfo {
+
+	switch l := link.(type) {
+	case *netlink.Gretun:
+		return tunnelEncapInfo(&TunnelInfo{
+			Kind:          TunnelGRE,
+			Local:         l.Local,
+			Remote:        l.Remote,
+			UnderlayIndex: int(l.Link),
+			Key:           l.IKey,
+			TTL:           int(l.Ttl),
+			TOS:           int(l.Tos),
+			PMTUDisc:      l.PMtuDisc != 0,
+		}, l.EncapType, l.EncapFlags, l.EncapSport, l.EncapDport)
+	case *netlink.Gretap:
+		return tunnelEncapInfo(&TunnelInfo{
+			Kind:          TunnelGRETap,
+			Local:         l.Local,
+			Remote:        l.Remote,
+			UnderlayIndex: int(l.Link),
+			Key:           l.IKey,
+			TTL:           int(l.Ttl),
+			TOS:           int(l.Tos),
+			PMTUDisc:      l.PMtuDisc != 0,
+		}, l.EncapType, l.EncapFlags, l.EncapSport, l.EncapDport)
+	case *netlink.Iptun:
+		return tunnelEncapInfo(&TunnelInfo{
+			Kind:          TunnelIPIP,
+			Local:         l.Local,
+			Remote:        l.Remote,
+			UnderlayIndex: int(l.Link),
+			TTL:           int(l.Ttl),
+			TOS:           int(l.Tos),
+			PMTUDisc:      l.PMtuDisc != 0,
+		}, l.EncapType, l.EncapFlags, l.EncapSport, l.EncapDport)
+	case *netlink.Sittun:
+		return tunnelEncapInfo(&TunnelInfo{
+			Kind:          TunnelSIT,
+			Local:         l.Local,
+			Remote:        l.Remote,
+			UnderlayIndex: int(l.Link),
+			TTL:           int(l.Ttl),
+			TOS:           int(l.Tos),
+			PMTUDisc:      l.PMtuDisc != 0,
+		}, l.EncapType, l.EncapFlags, l.EncapSport, l.EncapDport)
+	case *netlink.Ip6tnl:
+		// IPv6 tunnels always perform path MTU discovery.
+		return tunnelEncapInfo(&TunnelInfo{
+			Kind:          TunnelIP6Tnl,
+			Local:         l.Local,
+			Remote:        l.Remote,
+			UnderlayIndex: int(l.Link),
+			TTL:           int(l.Ttl),
+			TOS:           int(l.Tos),
+			PMTUDisc:      true,
+		}, l.EncapType, l.EncapFlags, l.EncapSport, l.EncapDport)
+	}
+
+	return nil
+}
+
+// Creates an IP tunnel interface of the given kind. GRE and GRETap tunnels
+// use an IPv6 underlay if the local or remote address is IPv6. The interface
+// is created administratively down.
+// This is equivalent to 'ip link add <name> type <kind> local <local>
+// remote <remote>'.
+func LinkCreateTunnel(name string, kind TunnelKind, opts *TunnelOptions) (*net.Interface, error) {
+
+	if opts == nil {
+		opts = &TunnelOptions{}
+	}
+
+	local, remote := opts.Local, opts.Remote
+
+	// Both endpoints are given in the same family, as it selects the
+	// underlay.
+	switch {
+	case local == nil && remote == nil:
+		if kind == TunnelIP6Tnl {
+			local = net.IPv6unspecified
+		} else {
+			local = net.IPv4zero
+		}
+	case local == nil:
+		local = unspecifiedLike(remote)
+	case remote != nil && (local.To4() == nil) != (remote.To4() == nil):
+		return nil, errors.New("Local and remote addresses must be of the same family")
+	}
+
+	ipv6 := local.To4() == nil
+
+	switch kind {
+	case TunnelIPIP, TunnelSIT:
+		if ipv6 {
+			return nil, errors.New("Tunnel kind requires an IPv4 underlay")
+		}
+	case TunnelIP6Tnl:
+		if !ipv6 {
+			return nil, errors.New("Tunnel kind requires an IPv6 underlay")
+		}
+	case TunnelGRE, TunnelGRETap:
+	default:
+		return nil, errors.New("Unsupported tunnel kind")
+	}
+
+	attrs := netlink.NewLinkAttrs()
+	attrs.Name = name
+
+	var underlay uint32
+	if opts.Underlay != nil {
+		underlay = uint32(opts.Underlay.Index)
+	}
+
+	var pmtudisc uint8 = 1
+	if opts.NoPMTUDisc {
+		pmtudisc = 0
+	}
+
+	var encapFlags uint16
+	if opts.EncapChecksum {
+		encapFlags = tunnelEncapFlagCsum
+	}
+
+	encapType := uint16(opts.Encap)
+	encapSport := uint16(opts.EncapSourcePort)
+	encapDport := uint16(opts.EncapDestinationPort)
+	ttl, tos := uint8(opts.TTL), uint8(opts.TOS)
+
+	var link netlink.Link
+
+	switch kind {
+	case TunnelGRE:
+		link = &netlink.Gretun{
+			LinkAttrs:  attrs,
+			Local:      local,
+			Remote:     remote,
+			Link:       underlay,
+			IKey:       opts.Key,
+			OKey:       opts.Key,
+			Ttl:        ttl,
+			Tos:        tos,
+			PMtuDisc:   pmtudisc,
+			EncapType:  encapType,
+			EncapFlags: encapFlags,
+			EncapSport: encapSport,
+			EncapDport: encapDport,
+		}
+	case TunnelGRETap:
+		link = &netlink.Gretap{
+			LinkAttrs:  attrs,
+			Local:      local,
+			Remote:     remote,
+			Link:       underlay,
+			IKey:       opts.Key,
+			OKey:       opts.Key,
+			Ttl:        ttl,
+			Tos:        tos,
+			PMtuDisc:   pmtudisc,
+			EncapType:  encapType,
+			EncapFlags: encapFlags,
+			EncapSport: encapSport,
+			EncapDport: encapDport,
+		}
+	case TunnelIPIP:
+		link = &netlink.Iptun{
+			LinkAttrs:  attrs,
+			Local:      local,
+			Remote:     remote,
+			Link:       underlay,
+			Proto:      unix.IPPROTO_IPIP,
+			Ttl:        ttl,
+			Tos:        tos,
+			PMtuDisc:   pmtudisc,
+			EncapType:  encapType,
+			EncapFlags: encapFlags,
+			EncapSport: encapSport,
+			EncapDport: encapDport,
+		}
+	case TunnelSIT:
+		link = &netlink.Sittun{
+			LinkAttrs:  attrs,
+			Local:      local,
+			Remote:     remote,
+			Link:       underlay,
+			Proto:      unix.IPPROTO_IPV6,
+			Ttl:        ttl,
+			Tos:        tos,
+			PMtuDisc:   pmtudisc,
+			EncapType:  encapType,
+			EncapFlags: encapFlags,
+			EncapSport: encapSport,
+			EncapDport: encapDport,
+		}
+	case TunnelIP6Tnl:
+		link = &netlink.Ip6tnl{
+			LinkAttrs:  attrs,
+			Local:      local,
+			Remote:     remote,
+			Link:       underlay,
+			Ttl:        ttl,
+			Tos:        tos,
+			EncapLimit: ip6TunnelDefaultEncapLimit,
+			EncapType:  encapType,
+			EncapFlags: encapFlags,
+			EncapSport: encapSport,
+			EncapDport: encapDport,
+		}
+	}
+
+	return linkAdd(link)
+}
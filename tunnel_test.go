@@ -0,0 +1,192 @@
+/*
+Copyright 2016 Arroyo Networks, LLC
+Copyright 2019 Project Contributors (See AUTHORS File).
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package splice_test
+
+import (
+	"github.com/arroyonetworks/splice"
+	"net"
+	"os"
+	"testing"
+)
+
+// ============================================================================
+//	LinkCreateTunnel
+// ============================================================================
+
+func TestLinkCreateTunnel_GRE(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Create a Keyed GRE Tunnel over IPv4
+	//			Expect: No error
+	// ------------------------------------------------------------------------
+
+	opts := &splice.TunnelOptions{
+		Local:  net.ParseIP("192.0.2.1"),
+		Remote: net.ParseIP("192.0.2.2"),
+		Key:    42,
+		TTL:    64,
+	}
+
+	intf, err := splice.LinkCreateTunnel(RandomIntfName("gre"), splice.TunnelGRE, opts)
+	SkipIfNotSupported(t, err)
+	if err != nil {
+		t.Fatal("LinkCreateTunnel Returned Error: ", err)
+	}
+
+	// (2)	Get the Link
+	//			Expect: The tunnel parameters are reported
+	// ------------------------------------------------------------------------
+
+	link, err := splice.LinkGet(intf)
+	if err != nil {
+		t.Fatal("LinkGet Returned Error: ", err)
+	}
+
+	info := link.Tunnel
+	if info == nil || info.Kind != splice.TunnelGRE {
+		t.Fatal("Link Is Not a GRE Tunnel: ", link.Kind)
+	}
+	if !info.Local.Equal(opts.Local) || !info.Remote.Equal(opts.Remote) {
+		t.Fatal("Tunnel Has Unexpected Endpoints: ", info.Local, info.Remote)
+	}
+	if info.Key != 42 || info.TTL != 64 || !info.PMTUDisc {
+		t.Fatal("Tunnel Has Unexpected Parameters: ", info)
+	}
+}
+
+func TestLinkCreateTunnel_IPIPWithFOU(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// The kernel only accepts FOU encapsulation once the fou module is loaded.
+	if _, err := os.Stat("/sys/module/fou"); err != nil {
+		SkipWithReason(t, "FOU Module is Not Loaded")
+	}
+
+	// (1)	Create an IPIP Tunnel with FOU Encapsulation
+	//			Expect: No error
+	// ------------------------------------------------------------------------
+
+	opts := &splice.TunnelOptions{
+		Local:                net.ParseIP("192.0.2.1"),
+		Remote:               net.ParseIP("192.0.2.2"),
+		Encap:                splice.TunnelEncapFOU,
+		EncapDestinationPort: 5555,
+	}
+
+	intf, err := splice.LinkCreateTunnel(RandomIntfName("ipip"), splice.TunnelIPIP, opts)
+	SkipIfNotSupported(t, err)
+	if err != nil {
+		t.Fatal("LinkCreateTunnel Returned Error: ", err)
+	}
+
+	// (2)	Get the Link
+	//			Expect: The encapsulation is reported
+	// ------------------------------------------------------------------------
+
+	link, err := splice.LinkGet(intf)
+	if err != nil {
+		t.Fatal("LinkGet Returned Error: ", err)
+	}
+
+	info := link.Tunnel
+	if info == nil || info.Kind != splice.TunnelIPIP {
+		t.Fatal("Link Is Not an IPIP Tunnel: ", link.Kind)
+	}
+	if info.Encap != splice.TunnelEncapFOU || info.EncapDestinationPort != 5555 {
+		t.Fatal("Tunnel Has Unexpected Encapsulation: ", info.Encap, info.EncapDestinationPort)
+	}
+}
+
+func TestLinkCreateTunnel_IP6Tnl(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Create an IPv6 Tunnel
+	//			Expect: No error
+	// ------------------------------------------------------------------------
+
+	opts := &splice.TunnelOptions{
+		Local:  net.ParseIP("2001:db8::1"),
+		Remote: net.ParseIP("2001:db8::2"),
+	}
+
+	intf, err := splice.LinkCreateTunnel(RandomIntfName("ip6tnl"), splice.TunnelIP6Tnl, opts)
+	SkipIfNotSupported(t, err)
+	if err != nil {
+		t.Fatal("LinkCreateTunnel Returned Error: ", err)
+	}
+
+	// (2)	Get the Link
+	//			Expect: The IPv6 endpoints are reported
+	// ------------------------------------------------------------------------
+
+	link, err := splice.LinkGet(intf)
+	if err != nil {
+		t.Fatal("LinkGet Returned Error: ", err)
+	}
+
+	info := link.Tunnel
+	if info == nil || info.Kind != splice.TunnelIP6Tnl {
+		t.Fatal("Link Is Not an IPv6 Tunnel: ", link.Kind)
+	}
+	if !info.Local.Equal(opts.Local) || !info.Remote.Equal(opts.Remote) {
+		t.Fatal("Tunnel Has Unexpected Endpoints: ", info.Local, info.Remote)
+	}
+}
+
+func TestLinkCreateTunnel_MismatchedFamilies(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Create a GRE Tunnel with an IPv4 Local and IPv6 Remote Address
+	//			Expect: Error
+	// ------------------------------------------------------------------------
+
+	opts := &splice.TunnelOptions{
+		Local:  net.ParseIP("192.0.2.1"),
+		Remote: net.ParseIP("2001:db8::2"),
+	}
+
+	if _, err := splice.LinkCreateTunnel(RandomIntfName("gre"), splice.TunnelGRE, opts); err == nil {
+		t.Fatal("LinkCreateTunnel Did Not Return an Error with Mismatched Families")
+	}
+}
+
+func TestLinkCreateTunnel_WrongUnderlay(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Create a SIT Tunnel over IPv6
+	//			Expect: Error, since SIT requires an IPv4 underlay
+	// ------------------------------------------------------------------------
+
+	opts := &splice.TunnelOptions{
+		Remote: net.ParseIP("2001:db8::2"),
+	}
+
+	if _, err := splice.LinkCreateTunnel(RandomIntfName("sit"), splice.TunnelSIT, opts); err == nil {
+		t.Fatal("LinkCreateTunnel Did Not Return an Error with an IPv6 Underlay")
+	}
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2016 Arroyo Networks, LLC
+Copyright 2019 Project Contributors (See AUTHORS File).
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package splice
+
+import (
+	"strconv"
+)
+
+// The kind of a TUN/TAP device.
+type TunTapMode int
+
+const (
+	TunTapModeTUN TunTapMode = iota + 1 // Layer 3 device, carrying IP packets.
+	TunTapModeTAP                       // Layer 2 device, carrying ethernet frames.
+)
+
+func (m TunTapMode) String() string {
+	switch m {
+	case TunTapModeTUN:
+		return "tun"
+	case TunTapModeTAP:
+		return "tap"
+	}
+	return strconv.Itoa(int(m))
+}
+
+// Optional parameters used when creating a TUN/TAP device.
+// Fields left at their zero value use the system defaults.
+type TunTapOptions struct {
+	Owner      string // User name or ID allowed to attach to the device.
+	Group      string // Group name or ID allowed to attach to the device.
+	Queues     int    // Number of queues, more than one creates a multiqueue device.
+	VnetHdr    bool   // Prefix packets with a virtio-net header.
+	PacketInfo bool   // Prefix packets with a protocol information header.
+	Open       bool   // Return the files of the device's queues.
+}
+
+// Describes the configuration of a TUN/TAP device.
+type TunTapInfo struct {
+	Mode       TunTapMode
+	Persistent bool
+	MultiQueue bool
+	VnetHdr    bool
+	PacketInfo bool
+	Queues     int // Number of attached queues.
+}
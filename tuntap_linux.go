@@ -0,0 +1,240 @@
+/*
+Copyright 2016 Arroyo Networks, LLC
+Copyright 2019 Project Contributors (See AUTHORS File).
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package splice
+
+import (
+	"errors"
+	"fmt"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+	"net"
+	"os"
+	"os/user"
+	"strconv"
+)
+
+// Provides TUN/TAP device management for Linux using the tun driver.
+
+const tunTapPath = "/dev/net/tun"
+
+// Returns the TUN/TAP configuration of a netlink tuntap link.
+func tunTapFromNetlink(tuntap *netlink.Tuntap) *TunTapInfo {
+
+	info := &TunTapInfo{
+		Persistent: !tuntap.NonPersist,
+		MultiQueue: tuntap.Flags&netlink.TUNTAP_MULTI_QUEUE != 0,
+		VnetHdr:    tuntap.Flags&netlink.TUNTAP_VNET_HDR != 0,
+		PacketInfo: tuntap.Flags&netlink.TUNTAP_NO_PI == 0,
+		Queues:     tuntap.Queues,
+	}
+
+	switch tuntap.Mode {
+	case netlink.TUNTAP_MODE_TUN:
+		info.Mode = TunTapModeTUN
+	case netlink.TUNTAP_MODE_TAP:
+		info.Mode = TunTapModeTAP
+	}
+
+	return info
+}
+
+// Resolves a user or group, given by name or numeric ID.
+func tunTapLookupID(name string, lookup func(string) (string, error)) (int, error) {
+
+	if id, err := strconv.Atoi(name); err == nil {
+		return id, nil
+	}
+
+	id, err := lookup(name)
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.Atoi(id)
+}
+
+// Attaches a new queue to the named TUN/TAP device, creating the device if it
+// does not exist. Returns the queue's file descriptor and the device name.
+func tunTapAttach(name string, flags uint16) (int, string, error) {
+
+	fd, err := unix.Open(tunTapPath, unix.O_RDWR|unix.O_CLOEXEC, 0)
+	if err != nil {
+		return -1, "", err
+	}
+
+	ifr, err := unix.NewIfreq(name)
+	if err != nil {
+		unix.Close(fd)
+		return -1, "", err
+	}
+	ifr.SetUint16(flags)
+
+	if err := unix.IoctlIfreq(fd, unix.TUNSETIFF, ifr); err != nil {
+		unix.Close(fd)
+		return -1, "", err
+	}
+
+	return fd, ifr.Name(), nil
+}
+
+// Creates a persistent TUN/TAP device, which remains after its queues are
+// closed until it is deleted with LinkDelete. An empty name lets the kernel
+// choose one. ErrExists is returned if a TUN/TAP device with the name already
+// exists. If opts.Open is set, the files of the device's queues are
+// returned for reading and writing packets, and must be closed by the caller.
+// The device is created administratively down.
+// This is equivalent to 'ip tuntap add <name> mode <mode> user <owner>
+// group <group>'.
+func LinkCreateTunTap(name string, mode TunTapMode, opts *TunTapOptions) (*net.Interface, []*os.File, error) {
+
+	if opts == nil {
+		opts = &TunTapOptions{}
+	}
+
+	var flags uint16
+
+	switch mode {
+	case TunTapModeTUN:
+		flags = unix.IFF_TUN
+	case TunTapModeTAP:
+		flags = unix.IFF_TAP
+	default:
+		return nil, nil, errors.New("Unsupported TUN/TAP mode")
+	}
+
+	if !opts.PacketInfo {
+		flags |= unix.IFF_NO_PI
+	}
+	if opts.VnetHdr {
+		flags |= unix.IFF_VNET_HDR
+	}
+
+	queues := opts.Queues
+	if queues > 1 {
+		flags |= unix.IFF_MULTI_QUEUE
+	} else {
+		queues = 1
+	}
+
+	owner, group := -1, -1
+
+	if opts.Owner != "" {
+		id, err := tunTapLookupID(opts.Owner, func(name string) (string, error) {
+			u, err := user.Lookup(name)
+			if err != nil {
+				return "", err
+			}
+			return u.Uid, nil
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		owner = id
+	}
+	if opts.Group != "" {
+		id, err := tunTapLookupID(opts.Group, func(name string) (string, error) {
+			g, err := user.LookupGroup(name)
+			if err != nil {
+				return "", err
+			}
+			return g.Gid, nil
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		group = id
+	}
+
+	var fds []int
+
+	closeAll := func() {
+		for _, fd := range fds {
+			unix.Close(fd)
+		}
+	}
+
+	// The first queue creates the device, which is then configured and
+	// persisted before any further queues are attached. It must not attach
+	// to an existing device, which would otherwise be released on failure.
+	fd, devName, err := tunTapAttach(name, flags|unix.IFF_TUN_EXCL)
+	if err != nil {
+		if errors.Is(err, unix.EBUSY) {
+			return nil, nil, fmt.Errorf("link %q: %w", name, ErrExists)
+		}
+		return nil, nil, err
+	}
+	fds = append(fds, fd)
+
+	if owner >= 0 {
+		if err := unix.IoctlSetInt(fd, unix.TUNSETOWNER, owner); err != nil {
+			closeAll()
+			return nil, nil, err
+		}
+	}
+	if group >= 0 {
+		if err := unix.IoctlSetInt(fd, unix.TUNSETGROUP, group); err != nil {
+			closeAll()
+			return nil, nil, err
+		}
+	}
+	if err := unix.IoctlSetInt(fd, unix.TUNSETPERSIST, 1); err != nil {
+		closeAll()
+		return nil, nil, err
+	}
+
+	// Once persisted, the device must be released again on failure.
+	removeAll := func() {
+		unix.IoctlSetInt(fds[0], unix.TUNSETPERSIST, 0)
+		closeAll()
+	}
+
+	for i := 1; i < queues; i++ {
+		fd, _, err := tunTapAttach(devName, flags)
+		if err != nil {
+			removeAll()
+			return nil, nil, err
+		}
+		fds = append(fds, fd)
+	}
+
+	intf, err := net.InterfaceByName(devName)
+	if err != nil {
+		removeAll()
+		return nil, nil, err
+	}
+
+	if !opts.Open {
+		closeAll()
+		return intf, nil, nil
+	}
+
+	// The descriptors are made non-blocking before wrapping them, so that
+	// reads and writes use Go's poller.
+	files := make([]*os.File, 0, len(fds))
+	for _, fd := range fds {
+		if err := unix.SetNonblock(fd, true); err != nil {
+			removeAll()
+			return nil, nil, err
+		}
+	}
+	for _, fd := range fds {
+		files = append(files, os.NewFile(uintptr(fd), tunTapPath))
+	}
+
+	return intf, files, nil
+}
@@ -0,0 +1,191 @@
+/*
+Copyright 2016 Arroyo Networks, LLC
+Copyright 2019 Project Contributors (See AUTHORS File).
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package splice_test
+
+import (
+	"errors"
+	"github.com/arroyonetworks/splice"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+//	LinkCreateTunTap
+// ============================================================================
+
+func TestLinkCreateTunTap(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Create a Persistent TAP Device
+	//			Expect: No error, and no files are returned
+	// ------------------------------------------------------------------------
+
+	opts := &splice.TunTapOptions{
+		Owner:   "0",
+		VnetHdr: true,
+	}
+
+	intf, files, err := splice.LinkCreateTunTap(RandomIntfName("tap"), splice.TunTapModeTAP, opts)
+	SkipIfNotSupported(t, err)
+	if err != nil {
+		t.Fatal("LinkCreateTunTap Returned Error: ", err)
+	}
+	if files != nil {
+		t.Fatal("LinkCreateTunTap Returned Files Without Open")
+	}
+
+	// (2)	Get the Link
+	//			Expect: A persistent TAP device is reported
+	// ------------------------------------------------------------------------
+
+	link, err := splice.LinkGet(intf)
+	if err != nil {
+		t.Fatal("LinkGet Returned Error: ", err)
+	}
+
+	info := link.TunTap
+	if info == nil {
+		t.Fatal("Link Is Not a TUN/TAP Device: ", link.Kind)
+	}
+	if info.Mode != splice.TunTapModeTAP || !info.Persistent || !info.VnetHdr || info.PacketInfo {
+		t.Fatal("TUN/TAP Device Has Unexpected Configuration: ", info)
+	}
+
+	// (3)	Configure an Address and a Route on the Device
+	//			Expect: No error
+	// ------------------------------------------------------------------------
+
+	if err := splice.LinkBringUp(intf); err != nil {
+		t.Fatal("LinkBringUp Returned Error: ", err)
+	}
+
+	address, _ := RandomIPv4Neighbour(t, intf)
+	if !IntfHasAddress(t, intf, address) {
+		t.Fatal("TUN/TAP Device Does Not Have the Address")
+	}
+
+	routeNet := RandomIPv4()
+	if err := splice.RouteAddViaInterface(routeNet, intf); err != nil {
+		t.Fatal("RouteAddViaInterface Returned Error: ", err)
+	}
+}
+
+func TestLinkCreateTunTap_MultiQueue(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Create a Multiqueue TUN Device and Open its Queues
+	//			Expect: No error, and a file per queue
+	// ------------------------------------------------------------------------
+
+	opts := &splice.TunTapOptions{
+		Queues: 2,
+		Open:   true,
+	}
+
+	intf, files, err := splice.LinkCreateTunTap(RandomIntfName("tun"), splice.TunTapModeTUN, opts)
+	SkipIfNotSupported(t, err)
+	if err != nil {
+		t.Fatal("LinkCreateTunTap Returned Error: ", err)
+	}
+	if len(files) != 2 {
+		t.Fatal("LinkCreateTunTap Returned Unexpected Number of Files: ", len(files))
+	}
+
+	// (2)	Get the Link
+	//			Expect: A multiqueue TUN device with both queues attached
+	// ------------------------------------------------------------------------
+
+	link, err := splice.LinkGet(intf)
+	if err != nil {
+		t.Fatal("LinkGet Returned Error: ", err)
+	}
+
+	info := link.TunTap
+	if info == nil || info.Mode != splice.TunTapModeTUN || !info.MultiQueue || info.Queues != 2 {
+		t.Fatal("TUN/TAP Device Has Unexpected Configuration: ", info)
+	}
+
+	// (3)	Close the Queues
+	//			Expect: The device persists
+	// ------------------------------------------------------------------------
+
+	for _, file := range files {
+		file.Close()
+	}
+
+	if !IntfExists(intf.Name) {
+		t.Fatal("TUN/TAP Device Did Not Persist After Closing its Queues")
+	}
+}
+
+func TestLinkCreateTunTap_Exists(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Create a Persistent TAP Device
+	// ------------------------------------------------------------------------
+
+	name := RandomIntfName("tap")
+
+	intf, _, err := splice.LinkCreateTunTap(name, splice.TunTapModeTAP, nil)
+	SkipIfNotSupported(t, err)
+	if err != nil {
+		t.Fatal("LinkCreateTunTap Returned Error: ", err)
+	}
+
+	// (2)	Create a Multi-Queue Device with the Same Name
+	//			Expect: ErrExists
+	// ------------------------------------------------------------------------
+
+	opts := &splice.TunTapOptions{Queues: 2}
+
+	_, _, err = splice.LinkCreateTunTap(name, splice.TunTapModeTAP, opts)
+	if !errors.Is(err, splice.ErrExists) {
+		t.Fatal("LinkCreateTunTap Did Not Return ErrExists: ", err)
+	}
+	if !strings.Contains(err.Error(), strconv.Quote(name)) {
+		t.Fatal("LinkCreateTunTap Error Does Not Name the Device: ", err)
+	}
+
+	// (3)	Expect: The original device still exists
+	// ------------------------------------------------------------------------
+
+	if _, err := splice.LinkGet(intf); err != nil {
+		t.Fatal("Original Device No Longer Exists: ", err)
+	}
+}
+
+func TestLinkCreateTunTap_InvalidMode(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Create a TUN/TAP Device with an Unknown Mode
+	//			Expect: Error
+	// ------------------------------------------------------------------------
+
+	if _, _, err := splice.LinkCreateTunTap(RandomIntfName("tun"), 42, nil); err == nil {
+		t.Fatal("LinkCreateTunTap Did Not Return an Error with Invalid Mode")
+	}
+}
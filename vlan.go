@@ -0,0 +1,57 @@
+/*
+Copyright 2016 Arroyo Networks, LLC
+Copyright 2019 Project Contributors (See AUTHORS File).
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package splice
+
+import (
+	"strconv"
+)
+
+// The tag protocol used by a VLAN interface.
+type VLANProtocol int
+
+const (
+	VLANProtocol8021Q  VLANProtocol = 0x8100 // IEEE 802.1Q, the default.
+	VLANProtocol8021AD VLANProtocol = 0x88a8 // IEEE 802.1ad (QinQ) service tag.
+)
+
+func (p VLANProtocol) String() string {
+	switch p {
+	case VLANProtocol8021Q:
+		return "802.1Q"
+	case VLANProtocol8021AD:
+		return "802.1ad"
+	}
+	return "0x" + strconv.FormatInt(int64(p), 16)
+}
+
+// Optional parameters used when creating a VLAN interface.
+// Fields left at their zero value use the system defaults.
+type VLANOptions struct {
+	NoReorderHeader bool              // Leave the VLAN header in place on received frames.
+	EgressQoSMap    map[uint32]uint32 // Maps socket buffer priorities to 802.1p priorities.
+	IngressQoSMap   map[uint32]uint32 // Maps 802.1p priorities to socket buffer priorities.
+}
+
+// Describes the configuration of a VLAN interface.
+type VLANInfo struct {
+	ID            int
+	Protocol      VLANProtocol
+	ReorderHeader bool
+	EgressQoSMap  map[uint32]uint32
+	IngressQoSMap map[uint32]uint32
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2016 Arroyo Networks, LLC
+Copyright 2019 Project Contributors (See AUTHORS File).
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package splice
+
+import (
+	"errors"
+	"github.com/vishvananda/netlink"
+	"net"
+)
+
+// Provides VLAN interface management for Linux using netlink.
+
+// Returns the VLAN configuration of a netlink VLAN link.
+func vlanFromNetlink(vlan *netlink.Vlan) *VLANInfo {
+
+	info := &VLANInfo{
+		ID:            vlan.VlanId,
+		Protocol:      VLANProtocol(vlan.VlanProtocol),
+		EgressQoSMap:  vlan.EgressQosMap,
+		IngressQoSMap: vlan.IngressQosMap,
+	}
+
+	if vlan.ReorderHdr != nil {
+		info.ReorderHeader = *vlan.ReorderHdr
+	}
+
+	return info
+}
+
+// Creates a VLAN interface on top of the given parent interface, tagging
+// frames with the VLAN ID vid. A protocol of 0 uses 802.1Q. For QinQ, create
+// an 802.1ad interface and then an 802.1Q interface with the 802.1ad interface
+// as its parent. The interface is created administratively down.
+// This is equivalent to 'ip link add link <parent.Name> name <name> type vlan
+// protocol <protocol> id <vid>'.
+func LinkCreateVLAN(parent *net.Interface, name string, vid int, protocol VLANProtocol, opts *VLANOptions) (*net.Interface, error) {
+
+	if vid < 1 || vid > 4094 {
+		return nil, errors.New("VLAN ID must be between 1 and 4094")
+	}
+
+	if protocol == 0 {
+		protocol = VLANProtocol8021Q
+	}
+	if protocol != VLANProtocol8021Q && protocol != VLANProtocol8021AD {
+		return nil, errors.New("Unsupported VLAN protocol")
+	}
+
+	attrs := netlink.NewLinkAttrs()
+	attrs.Name = name
+	attrs.ParentIndex = parent.Index
+
+	vlan := &netlink.Vlan{
+		LinkAttrs:    attrs,
+		VlanId:       vid,
+		VlanProtocol: netlink.VlanProtocol(protocol),
+	}
+
+	if opts != nil {
+		if opts.NoReorderHeader {
+			reorder := false
+			vlan.ReorderHdr = &reorder
+		}
+		vlan.EgressQosMap = opts.EgressQoSMap
+		vlan.IngressQosMap = opts.IngressQoSMap
+	}
+
+	return linkAdd(vlan)
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2016 Arroyo Networks, LLC
+Copyright 2019 Project Contributors (See AUTHORS File).
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package splice_test
+
+import (
+	"github.com/arroyonetworks/splice"
+	"testing"
+)
+
+// ============================================================================
+//	LinkCreateVLAN
+// ============================================================================
+
+func TestLinkCreateVLAN(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	parent := GetDummyUpIntf(t)
+
+	// (1)	Create a VLAN Interface with Options
+	//			Expect: No error
+	// ------------------------------------------------------------------------
+
+	opts := &splice.VLANOptions{
+		NoReorderHeader: true,
+		EgressQoSMap:    map[uint32]uint32{1: 5},
+	}
+
+	intf, err := splice.LinkCreateVLAN(parent, RandomIntfName("vlan"), 100, 0, opts)
+	SkipIfNotSupported(t, err)
+	if err != nil {
+		t.Fatal("LinkCreateVLAN Returned Error: ", err)
+	}
+
+	// (2)	Get the Link
+	//			Expect: The VLAN configuration is reported
+	// ------------------------------------------------------------------------
+
+	link, err := splice.LinkGet(intf)
+	if err != nil {
+		t.Fatal("LinkGet Returned Error: ", err)
+	}
+
+	if link.VLAN == nil || link.ParentIndex != parent.Index {
+		t.Fatal("Link Is Not a VLAN of the Parent: ", link)
+	}
+	if link.VLAN.ID != 100 || link.VLAN.Protocol != splice.VLANProtocol8021Q {
+		t.Fatal("VLAN Has Unexpected ID or Protocol: ", link.VLAN.ID, link.VLAN.Protocol)
+	}
+	if link.VLAN.ReorderHeader {
+		t.Fatal("VLAN Reorders Headers Despite Being Disabled")
+	}
+	if link.VLAN.EgressQoSMap[1] != 5 {
+		t.Fatal("VLAN Has Unexpected Egress QoS Map: ", link.VLAN.EgressQoSMap)
+	}
+}
+
+func TestLinkCreateVLAN_QinQ(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	parent := GetDummyUpIntf(t)
+
+	// (1)	Create an 802.1ad Service VLAN
+	//			Expect: No error
+	// ------------------------------------------------------------------------
+
+	outer, err := splice.LinkCreateVLAN(parent, RandomIntfName("svlan"), 10, splice.VLANProtocol8021AD, nil)
+	SkipIfNotSupported(t, err)
+	if err != nil {
+		t.Fatal("LinkCreateVLAN Returned Error: ", err)
+	}
+
+	// (2)	Create an 802.1Q Customer VLAN on the Service VLAN
+	//			Expect: No error, and the customer VLAN is stacked
+	// ------------------------------------------------------------------------
+
+	inner, err := splice.LinkCreateVLAN(outer, RandomIntfName("cvlan"), 20, splice.VLANProtocol8021Q, nil)
+	if err != nil {
+		t.Fatal("LinkCreateVLAN Returned Error: ", err)
+	}
+
+	link, err := splice.LinkGet(outer)
+	if err != nil {
+		t.Fatal("LinkGet Returned Error: ", err)
+	}
+	if link.VLAN == nil || link.VLAN.Protocol != splice.VLANProtocol8021AD {
+		t.Fatal("Service VLAN Is Not 802.1ad: ", link.VLAN)
+	}
+
+	link, err = splice.LinkGet(inner)
+	if err != nil {
+		t.Fatal("LinkGet Returned Error: ", err)
+	}
+	if link.VLAN == nil || link.ParentIndex != outer.Index {
+		t.Fatal("Customer VLAN Is Not Stacked on the Service VLAN: ", link)
+	}
+}
+
+func TestLinkCreateVLAN_InvalidID(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Create a VLAN Interface with an Out of Range ID
+	//			Expect: Error
+	// ------------------------------------------------------------------------
+
+	if _, err := splice.LinkCreateVLAN(config.loopbackIntf, RandomIntfName("vlan"), 4095, 0, nil); err == nil {
+		t.Fatal("LinkCreateVLAN Did Not Return an Error with Invalid VLAN ID")
+	}
+}
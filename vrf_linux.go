@@ -0,0 +1,98 @@
+/*
+Copyright 2016 Arroyo Networks, LLC
+Copyright 2019 Project Contributors (See AUTHORS File).
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package splice
+
+import (
+	"errors"
+	"github.com/vishvananda/netlink"
+	"net"
+)
+
+// Provides VRF interface management for Linux using netlink.
+
+// Returns the VRF configuration of a netlink VRF link.
+func vrfFromNetlink(vrf *netlink.Vrf) *VRFInfo {
+	return &VRFInfo{
+		Table: int(vrf.Table),
+	}
+}
+
+// Returns the routing table of the given VRF interface. An error is returned
+// if the interface is not a VRF.
+func vrfTable(vrf *net.Interface) (int, error) {
+
+	link, err := netlink.LinkByIndex(vrf.Index)
+	if err != nil {
+		return 0, err
+	}
+
+	nlVRF, ok := link.(*netlink.Vrf)
+	if !ok {
+		return 0, errors.New("Interface " + vrf.Name + " is not a VRF")
+	}
+
+	return int(nlVRF.Table), nil
+}
+
+// Creates a VRF interface bound to the given routing table. Routes for the
+// interfaces enslaved to the VRF are looked up in this table. The interface
+// is created administratively down.
+// This is equivalent to 'ip link add <name> type vrf table <table>'.
+func LinkCreateVRF(name string, table int) (*net.Interface, error) {
+
+	if table <= 0 {
+		return nil, errors.New("Invalid VRF routing table")
+	}
+
+	attrs := netlink.NewLinkAttrs()
+	attrs.Name = name
+
+	return linkAdd(&netlink.Vrf{
+		LinkAttrs: attrs,
+		Table:     uint32(table),
+	})
+}
+
+// Moves the interface into the VRF. The routes of the interface's connected
+// networks are moved into the VRF's routing table by the kernel.
+// This is equivalent to 'ip link set <intf.Name> master <vrf.Name>'.
+func VRFEnslave(vrf *net.Interface, intf *net.Interface) error {
+
+	if _, err := vrfTable(vrf); err != nil {
+		return err
+	}
+
+	link, err := netlink.LinkByIndex(intf.Index)
+	if err != nil {
+		return err
+	}
+
+	return netlink.LinkSetMasterByIndex(link, vrf.Index)
+}
+
+// Moves the interface out of its VRF, back into the default routing domain.
+// This is equivalent to 'ip link set <intf.Name> nomaster'.
+func VRFRelease(intf *net.Interface) error {
+
+	link, err := netlink.LinkByIndex(intf.Index)
+	if err != nil {
+		return err
+	}
+
+	return netlink.LinkSetNoMaster(link)
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2016 Arroyo Networks, LLC
+Copyright 2019 Project Contributors (See AUTHORS File).
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package splice_test
+
+import (
+	"github.com/arroyonetworks/splice"
+	"testing"
+)
+
+// ============================================================================
+//	LinkCreateVRF
+// ============================================================================
+
+func TestLinkCreateVRF(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Create a VRF Interface
+	//			Expect: No error
+	// ------------------------------------------------------------------------
+
+	vrf, err := splice.LinkCreateVRF(RandomIntfName("vrf"), 100)
+	SkipIfNotSupported(t, err)
+	if err != nil {
+		t.Fatal("LinkCreateVRF Returned Error: ", err)
+	}
+
+	// (2)	Get the Link
+	//			Expect: A VRF bound to the table is reported
+	// ------------------------------------------------------------------------
+
+	link, err := splice.LinkGet(vrf)
+	if err != nil {
+		t.Fatal("LinkGet Returned Error: ", err)
+	}
+
+	if link.VRF == nil {
+		t.Fatal("Link Is Not a VRF: ", link.Kind)
+	}
+	if link.VRF.Table != 100 {
+		t.Fatal("VRF Has Unexpected Table: ", link.VRF.Table)
+	}
+}
+
+func TestLinkCreateVRF_InvalidTable(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Create a VRF Interface Without a Table
+	//			Expect: Error
+	// ------------------------------------------------------------------------
+
+	if _, err := splice.LinkCreateVRF(RandomIntfName("vrf"), 0); err == nil {
+		t.Fatal("LinkCreateVRF Did Not Return an Error with Invalid Table")
+	}
+}
+
+// ============================================================================
+//	VRFEnslave / VRFRelease
+// ============================================================================
+
+func TestVRFEnslave(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	vrf, err := splice.LinkCreateVRF(RandomIntfName("vrf"), 100)
+	SkipIfNotSupported(t, err)
+	if err != nil {
+		t.Fatal("LinkCreateVRF Returned Error: ", err)
+	}
+	if err := splice.LinkBringUp(vrf); err != nil {
+		t.Fatal("LinkBringUp Returned Error: ", err)
+	}
+
+	intf := GetDummyUpIntf(t)
+
+	// (1)	Move the Interface into the VRF
+	//			Expect: No error
+	// ------------------------------------------------------------------------
+
+	if err := splice.VRFEnslave(vrf, intf); err != nil {
+		t.Fatal("VRFEnslave Returned Error: ", err)
+	}
+
+	link, err := splice.LinkGet(intf)
+	if err != nil {
+		t.Fatal("LinkGet Returned Error: ", err)
+	}
+	if link.MasterIndex != vrf.Index {
+		t.Fatal("Interface Is Not Enslaved to the VRF: ", link.MasterIndex)
+	}
+
+	// (2)	Add a Route in the VRF
+	//			Expect: The route is placed in the VRF's table
+	// ------------------------------------------------------------------------
+
+	routeNet := RandomIPv4()
+
+	if err := splice.RouteAddViaInterfaceWithOptions(routeNet, intf, &splice.RouteOptions{VRF: vrf}); err != nil {
+		t.Fatal("RouteAddViaInterfaceWithOptions Returned Error: ", err)
+	}
+	if !splice.RouteHasEntryFiltered(&splice.RouteFilter{Destination: routeNet, Table: 100}) {
+		t.Fatal("Route Is Not Present in the VRF Table")
+	}
+
+	// (3)	List the Routes of the VRF
+	//			Expect: The route is returned
+	// ------------------------------------------------------------------------
+
+	routes, err := splice.RouteList(&splice.RouteFilter{Destination: routeNet, VRF: vrf})
+	if err != nil {
+		t.Fatal("RouteList Returned Error: ", err)
+	}
+	if len(routes) != 1 || routes[0].Table != 100 {
+		t.Fatal("RouteList Did Not Return the VRF Route: ", routes)
+	}
+
+	// (4)	Look Up the Route Within the VRF
+	//			Expect: The route out of the enslaved interface is selected
+	// ------------------------------------------------------------------------
+
+	route, err := splice.RouteLookup(routeNet.IP, &splice.RouteLookupOptions{VRF: vrf})
+	if err != nil {
+		t.Fatal("RouteLookup Returned Error: ", err)
+	}
+	if route.Interface == nil || route.Interface.Index != intf.Index {
+		t.Fatal("RouteLookup Returned Unexpected Interface: ", route.Interface)
+	}
+
+	// (5)	Delete the Route from the VRF
+	//			Expect: No error
+	// ------------------------------------------------------------------------
+
+	if err := splice.RouteDelete(routeNet, &splice.RouteDeleteOptions{VRF: vrf}); err != nil {
+		t.Fatal("RouteDelete Returned Error: ", err)
+	}
+	if splice.RouteHasEntryFiltered(&splice.RouteFilter{Destination: routeNet, Table: 100}) {
+		t.Fatal("Route Is Still Present in the VRF Table")
+	}
+
+	// (6)	Move the Interface out of the VRF
+	//			Expect: The interface no longer has a master
+	// ------------------------------------------------------------------------
+
+	if err := splice.VRFRelease(intf); err != nil {
+		t.Fatal("VRFRelease Returned Error: ", err)
+	}
+
+	link, err = splice.LinkGet(intf)
+	if err != nil {
+		t.Fatal("LinkGet Returned Error: ", err)
+	}
+	if link.MasterIndex != 0 {
+		t.Fatal("Interface Is Still Enslaved: ", link.MasterIndex)
+	}
+}
+
+func TestVRFEnslave_NotVRF(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	intf := GetDummyUpIntf(t)
+
+	// (1)	Enslave an Interface to a Non-VRF Interface
+	//			Expect: Error
+	// ------------------------------------------------------------------------
+
+	if err := splice.VRFEnslave(config.loopbackIntf, intf); err == nil {
+		t.Fatal("VRFEnslave Did Not Return an Error with a Non-VRF Interface")
+	}
+
+	// (2)	Add a Route in a Non-VRF Interface
+	//			Expect: Error
+	// ------------------------------------------------------------------------
+
+	opts := &splice.RouteOptions{VRF: config.loopbackIntf}
+
+	if err := splice.RouteAddViaInterfaceWithOptions(RandomIPv4(), intf, opts); err == nil {
+		t.Fatal("RouteAddViaInterfaceWithOptions Did Not Return an Error with a Non-VRF Interface")
+	}
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2016 Arroyo Networks, LLC
+Copyright 2019 Project Contributors (See AUTHORS File).
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package splice
+
+import (
+	"net"
+)
+
+// The IANA assigned destination port for VXLAN.
+const VXLANPort = 4789
+
+// Optional parameters used when creating a VXLAN interface.
+// Fields left at their zero value use the system defaults.
+type VXLANOptions struct {
+	Local          net.IP         // Source address of encapsulated packets.
+	Remote         net.IP         // Unicast address of a single remote VTEP.
+	Group          net.IP         // Multicast group used to reach remote VTEPs.
+	Underlay       *net.Interface // Device used to reach remote VTEPs, required with Group.
+	Port           int            // Destination UDP port (default: VXLANPort).
+	NoLearning     bool           // Do not learn remote MAC addresses from received packets.
+	TTL            int            // TTL of encapsulated packets.
+	TOS            int            // TOS of encapsulated packets.
+	UDPChecksum    bool           // Calculate UDP checksums over IPv4.
+	UDP6ZeroCSumTx bool           // Skip UDP checksums when transmitting over IPv6.
+	UDP6ZeroCSumRx bool           // Accept zero UDP checksums when receiving over IPv6.
+	GroupPolicyExt bool           // Enable the Group Based Policy (GBP) extension.
+}
+
+// Describes the configuration of a VXLAN interface.
+type VXLANInfo struct {
+	VNI            int
+	Local          net.IP
+	Remote         net.IP // Set for unicast remote VTEPs.
+	Group          net.IP // Set for multicast groups.
+	UnderlayIndex  int
+	Port           int
+	Learning       bool
+	TTL            int
+	TOS            int
+	UDPChecksum    bool
+	UDP6ZeroCSumTx bool
+	UDP6ZeroCSumRx bool
+	GroupPolicyExt bool
+}
+
+// A static forwarding database entry of a VXLAN interface, directing frames
+// for a MAC address to a remote VTEP. The all-zeros MAC address selects the
+// default destinations used for broadcast and unknown unicast frames.
+type VXLANFDBEntry struct {
+	HardwareAddr net.HardwareAddr
+	Remote       net.IP
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2016 Arroyo Networks, LLC
+Copyright 2019 Project Contributors (See AUTHORS File).
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package splice
+
+import (
+	"errors"
+	"fmt"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+	"net"
+)
+
+// Provides VXLAN interface management for Linux using netlink.
+
+// Returns the VXLAN configuration of a netlink VXLAN link.
+func vxlanFromNetlink(vxlan *netlink.Vxlan) *VXLANInfo {
+
+	info := &VXLANInfo{
+		VNI:            vxlan.VxlanId,
+		Local:          vxlan.SrcAddr,
+		UnderlayIndex:  vxlan.VtepDevIndex,
+		Port:           vxlan.Port,
+		Learning:       vxlan.Learning,
+		TTL:            vxlan.TTL,
+		TOS:            vxlan.TOS,
+		UDPChecksum:    vxlan.UDPCSum,
+		UDP6ZeroCSumTx: vxlan.UDP6ZeroCSumTx,
+		UDP6ZeroCSumRx: vxlan.UDP6ZeroCSumRx,
+		GroupPolicyExt: vxlan.GBP,
+	}
+
+	if vxlan.Group.IsMulticast() {
+		info.Group = vxlan.Group
+	} else if vxlan.Group != nil && !vxlan.Group.IsUnspecified() {
+		info.Remote = vxlan.Group
+	}
+
+	return info
+}
+
+// Creates a VXLAN interface with the given VXLAN network identifier. Remote
+// VTEPs are either given by a unicast remote address, a multicast group, or
+// through static forwarding database entries. The interface is created
+// administratively down.
+// This is equivalent to 'ip link add <name> type vxlan id <vni>'.
+func LinkCreateVXLAN(name string, vni int, opts *VXLANOptions) (*net.Interface, error) {
+
+	if vni < 0 || vni > 0xffffff {
+		return nil, errors.New("VXLAN network identifier must be between 0 and 16777215")
+	}
+
+	if opts == nil {
+		opts = &VXLANOptions{}
+	}
+
+	if opts.Remote != nil && opts.Group != nil {
+		return nil, errors.New("Remote and group addresses are mutually exclusive")
+	}
+	if opts.Group != nil && !opts.Group.IsMulticast() {
+		return nil, errors.New("Group address is not a multicast address")
+	}
+
+	attrs := netlink.NewLinkAttrs()
+	attrs.Name = name
+
+	vxlan := &netlink.Vxlan{
+		LinkAttrs:      attrs,
+		VxlanId:        vni,
+		SrcAddr:        opts.Local,
+		Group:          opts.Remote,
+		Port:           opts.Port,
+		Learning:       !opts.NoLearning,
+		TTL:            opts.TTL,
+		TOS:            opts.TOS,
+		UDPCSum:        opts.UDPChecksum,
+		UDP6ZeroCSumTx: opts.UDP6ZeroCSumTx,
+		UDP6ZeroCSumRx: opts.UDP6ZeroCSumRx,
+		GBP:            opts.GroupPolicyExt,
+	}
+
+	if opts.Group != nil {
+		vxlan.Group = opts.Group
+	}
+	if opts.Underlay != nil {
+		vxlan.VtepDevIndex = opts.Underlay.Index
+	}
+	if vxlan.Port == 0 {
+		vxlan.Port = VXLANPort
+	}
+
+	return linkAdd(vxlan)
+}
+
+// Returns the netlink neighbour describing a VXLAN forwarding database entry.
+func vxlanFDBNeigh(intf *net.Interface, mac net.HardwareAddr, remote net.IP) *netlink.Neigh {
+	return &netlink.Neigh{
+		LinkIndex:    intf.Index,
+		Family:       unix.AF_BRIDGE,
+		Flags:        netlink.NTF_SELF,
+		State:        netlink.NUD_PERMANENT,
+		HardwareAddr: mac,
+		IP:           remote,
+	}
+}
+
+// Adds a static forwarding database entry to a VXLAN interface, directing
+// frames for the MAC address to the given remote VTEP. Several remote VTEPs
+// may be added for the same MAC address, such as the all-zeros address used
+// to flood broadcast and unknown unicast frames.
+// This is equivalent to 'bridge fdb append <mac> dev <intf.Name> dst <remote>'.
+func VXLANFDBAdd(intf *net.Interface, mac net.HardwareAddr, remote net.IP) error {
+	return netlink.NeighAppend(vxlanFDBNeigh(intf, mac, remote))
+}
+
+// Removes a static forwarding database entry from a VXLAN interface.
+// Returns ErrNotFound if there are no entries for the MAC address.
+// This is equivalent to 'bridge fdb del <mac> dev <intf.Name> dst <remote>'.
+func VXLANFDBDelete(intf *net.Interface, mac net.HardwareAddr, remote net.IP) error {
+
+	err := netlink.NeighDel(vxlanFDBNeigh(intf, mac, remote))
+	if errors.Is(err, unix.ENOENT) {
+		return fmt.Errorf("fdb entry %s dst %s: %w", mac, remote, ErrNotFound)
+	}
+
+	return err
+}
+
+// Returns the forwarding database entries of a VXLAN interface which direct
+// frames to a remote VTEP.
+// This is equivalent to 'bridge fdb show dev <intf.Name>'.
+func VXLANFDBList(intf *net.Interface) ([]*VXLANFDBEntry, error) {
+
+	neighs, err := netlink.NeighList(intf.Index, unix.AF_BRIDGE)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []*VXLANFDBEntry
+	for _, neigh := range neighs {
+		if neigh.IP == nil {
+			continue
+		}
+		entries = append(entries, &VXLANFDBEntry{
+			HardwareAddr: neigh.HardwareAddr,
+			Remote:       neigh.IP,
+		})
+	}
+
+	return entries, nil
+}
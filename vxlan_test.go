@@ -0,0 +1,172 @@
+/*
+Copyright 2016 Arroyo Networks, LLC
+Copyright 2019 Project Contributors (See AUTHORS File).
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package splice_test
+
+import (
+	"errors"
+	"github.com/arroyonetworks/splice"
+	"net"
+	"testing"
+)
+
+// ============================================================================
+//	LinkCreateVXLAN
+// ============================================================================
+
+func TestLinkCreateVXLAN(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Create a Unicast VXLAN Interface
+	//			Expect: No error
+	// ------------------------------------------------------------------------
+
+	opts := &splice.VXLANOptions{
+		Local:          net.ParseIP("192.0.2.1"),
+		Remote:         net.ParseIP("192.0.2.2"),
+		NoLearning:     true,
+		TTL:            64,
+		GroupPolicyExt: true,
+	}
+
+	intf, err := splice.LinkCreateVXLAN(RandomIntfName("vxlan"), 4242, opts)
+	SkipIfNotSupported(t, err)
+	if err != nil {
+		t.Fatal("LinkCreateVXLAN Returned Error: ", err)
+	}
+
+	// (2)	Get the Link
+	//			Expect: The VXLAN configuration is reported
+	// ------------------------------------------------------------------------
+
+	link, err := splice.LinkGet(intf)
+	if err != nil {
+		t.Fatal("LinkGet Returned Error: ", err)
+	}
+
+	info := link.VXLAN
+	if info == nil {
+		t.Fatal("Link Is Not a VXLAN Link: ", link.Kind)
+	}
+	if info.VNI != 4242 || info.Port != splice.VXLANPort {
+		t.Fatal("VXLAN Has Unexpected VNI or Port: ", info.VNI, info.Port)
+	}
+	if !info.Local.Equal(opts.Local) || !info.Remote.Equal(opts.Remote) || info.Group != nil {
+		t.Fatal("VXLAN Has Unexpected Addresses: ", info.Local, info.Remote, info.Group)
+	}
+	if info.Learning || info.TTL != 64 || !info.GroupPolicyExt {
+		t.Fatal("VXLAN Has Unexpected Options: ", info)
+	}
+}
+
+func TestLinkCreateVXLAN_RemoteAndGroup(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Create a VXLAN Interface with both a Remote and a Group
+	//			Expect: Error
+	// ------------------------------------------------------------------------
+
+	opts := &splice.VXLANOptions{
+		Remote: net.ParseIP("192.0.2.2"),
+		Group:  net.ParseIP("239.1.1.1"),
+	}
+
+	if _, err := splice.LinkCreateVXLAN(RandomIntfName("vxlan"), 1, opts); err == nil {
+		t.Fatal("LinkCreateVXLAN Did Not Return an Error with Remote and Group")
+	}
+}
+
+// ============================================================================
+//	VXLANFDBAdd / VXLANFDBList / VXLANFDBDelete
+// ============================================================================
+
+func TestVXLANFDB(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	intf, err := splice.LinkCreateVXLAN(RandomIntfName("vxlan"), 100, nil)
+	SkipIfNotSupported(t, err)
+	if err != nil {
+		t.Fatal("LinkCreateVXLAN Returned Error: ", err)
+	}
+
+	flood := net.HardwareAddr{0, 0, 0, 0, 0, 0}
+	remoteA := net.ParseIP("192.0.2.10")
+	remoteB := net.ParseIP("192.0.2.11")
+
+	// (1)	Add Two Flooding Entries
+	//			Expect: No error
+	// ------------------------------------------------------------------------
+
+	for _, remote := range []net.IP{remoteA, remoteB} {
+		if err := splice.VXLANFDBAdd(intf, flood, remote); err != nil {
+			t.Fatal("VXLANFDBAdd Returned Error: ", err)
+		}
+	}
+
+	// (2)	List the Entries
+	//			Expect: Both remote VTEPs are listed
+	// ------------------------------------------------------------------------
+
+	entries, err := splice.VXLANFDBList(intf)
+	if err != nil {
+		t.Fatal("VXLANFDBList Returned Error: ", err)
+	}
+
+	found := map[string]bool{}
+	for _, entry := range entries {
+		if entry.HardwareAddr.String() == flood.String() {
+			found[entry.Remote.String()] = true
+		}
+	}
+	if !found[remoteA.String()] || !found[remoteB.String()] {
+		t.Fatal("Not All Remote VTEPs Were Listed: ", found)
+	}
+
+	// (3)	Delete an Entry
+	//			Expect: No error, and only the other entry remains
+	// ------------------------------------------------------------------------
+
+	if err := splice.VXLANFDBDelete(intf, flood, remoteA); err != nil {
+		t.Fatal("VXLANFDBDelete Returned Error: ", err)
+	}
+
+	entries, err = splice.VXLANFDBList(intf)
+	if err != nil {
+		t.Fatal("VXLANFDBList Returned Error: ", err)
+	}
+	if len(entries) != 1 || !entries[0].Remote.Equal(remoteB) {
+		t.Fatal("Unexpected Entries After Delete: ", entries)
+	}
+
+	// (4)	Delete the Last Entry Twice
+	//			Expect: ErrNotFound once the MAC address has no entries left
+	// ------------------------------------------------------------------------
+
+	if err := splice.VXLANFDBDelete(intf, flood, remoteB); err != nil {
+		t.Fatal("VXLANFDBDelete Returned Error: ", err)
+	}
+
+	if err := splice.VXLANFDBDelete(intf, flood, remoteB); !errors.Is(err, splice.ErrNotFound) {
+		t.Fatal("VXLANFDBDelete Did Not Return a Not Found Error: ", err)
+	}
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2016 Arroyo Networks, LLC
+Copyright 2019 Project Contributors (See AUTHORS File).
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package splice
+
+import (
+	"context"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+	"net"
+	"time"
+)
+
+// Provides helpers which block until a network condition is met.
+// Conditions are re-checked whenever a relevant netlink event is received,
+// and periodically in case an event is missed or the subscription failed.
+
+// Interval at which conditions are re-checked without an event.
+const waitPollInterval = time.Second
+
+// Blocks until check reports the condition is met, returns an error, or the
+// context is done. The condition is re-checked each time notify is signalled
+// and every waitPollInterval.
+func waitUntil(ctx context.Context, notify <-chan struct{}, check func() (bool, error)) error {
+
+	ticker := time.NewTicker(waitPollInterval)
+	defer ticker.Stop()
+
+	for {
+		if ok, err := check(); err != nil || ok {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-notify:
+		case <-ticker.C:
+		}
+	}
+}
+
+// Signals notify without blocking, coalescing pending notifications.
+func waitSignal(notify chan<- struct{}) {
+	select {
+	case notify <- struct{}{}:
+	default:
+	}
+}
+
+// Blocks until the given interface is operationally up, or the context is
+// done. Links which do not report an operational state, such as loopback,
+// are considered up once they are administratively up and have a carrier.
+func WaitLinkOperUp(ctx context.Context, intf *net.Interface) error {
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	notify := make(chan struct{}, 1)
+
+	if events, err := LinkSubscribe(ctx); err == nil {
+		go func() {
+			for event := range events {
+				if event.Interface.Index == intf.Index {
+					waitSignal(notify)
+				}
+			}
+		}()
+	}
+
+	return waitUntil(ctx, notify, func() (bool, error) {
+
+		link, err := netlink.LinkByIndex(intf.Index)
+		if err != nil {
+			return false, err
+		}
+
+		attrs := link.Attrs()
+
+		switch LinkOperState(attrs.OperState) {
+		case LinkOperUp:
+			return true, nil
+		case LinkOperUnknown:
+			up := uint32(unix.IFF_UP | unix.IFF_LOWER_UP)
+			return attrs.RawFlags&up == up, nil
+		}
+		return false, nil
+	})
+}
+
+// Blocks until the given address is configured on the interface and usable,
+// or the context is done. An address is usable once duplicate address
+// detection has completed. Returns ErrDuplicateAddress if duplicate address
+// detection failed.
+func WaitAddressUsable(ctx context.Context, intf *net.Interface, address *net.IPNet) error {
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	notify := make(chan struct{}, 1)
+
+	if events, err := AddressSubscribe(ctx); err == nil {
+		go func() {
+			for event := range events {
+				if event.Address.InterfaceIndex == intf.Index {
+					waitSignal(notify)
+				}
+			}
+		}()
+	}
+
+	return waitUntil(ctx, notify, func() (bool, error) {
+
+		addrs, err := AddressListDetailed(intf)
+		if err != nil {
+			return false, err
+		}
+
+		for _, addr := range addrs {
+			if !addr.IPNet.IP.Equal(address.IP) || addr.IPNet.Mask.String() != address.Mask.String() {
+				continue
+			}
+			if addr.Flags&AddressFlagDADFailed != 0 {
+				return false, ErrDuplicateAddress
+			}
+			return addr.Flags&AddressFlagTentative == 0, nil
+		}
+		return false, nil
+	})
+}
+
+// Blocks until the main routing table has an entry for the given destination
+// network, or the context is done.
+func WaitRoutePresent(ctx context.Context, destination *net.IPNet) error {
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	notify := make(chan struct{}, 1)
+
+	if events, err := RouteSubscribe(ctx); err == nil {
+		go func() {
+			for event := range events {
+				if event.Route.Destination != nil && event.Route.Destination.String() == destination.String() {
+					waitSignal(notify)
+				}
+			}
+		}()
+	}
+
+	return waitUntil(ctx, notify, func() (bool, error) {
+		return RouteHasEntry(destination), nil
+	})
+}
@@ -0,0 +1,272 @@
+/*
+Copyright 2016 Arroyo Networks, LLC
+Copyright 2019 Project Contributors (See AUTHORS File).
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package splice_test
+
+import (
+	"context"
+	"errors"
+	"github.com/arroyonetworks/splice"
+	"net"
+	"testing"
+	"time"
+)
+
+// ============================================================================
+//	WaitLinkOperUp
+// ============================================================================
+
+func TestWaitLinkOperUp(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// (1)	Wait for the Loopback Interface
+	//			Expect: No error, since loopback is up with a carrier
+	// ------------------------------------------------------------------------
+
+	if err := splice.WaitLinkOperUp(ctx, config.loopbackIntf); err != nil {
+		t.Fatal("WaitLinkOperUp Returned Error: ", err)
+	}
+}
+
+func TestWaitLinkOperUp_Deadline(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	// (1)	Wait for a Downed Link
+	//			Expect: The context deadline is exceeded
+	// ------------------------------------------------------------------------
+
+	intf := GetDummyDownIntf(t)
+
+	if err := splice.WaitLinkOperUp(ctx, intf); err != context.DeadlineExceeded {
+		t.Fatal("WaitLinkOperUp Did Not Return a Deadline Error: ", err)
+	}
+}
+
+// ============================================================================
+//	WaitAddressUsable
+// ============================================================================
+
+func TestWaitAddressUsable(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// (1)	Add a Loopback Address
+	// ------------------------------------------------------------------------
+
+	newAddr := RandomIPv4()
+
+	if err := splice.AddressAdd(config.loopbackIntf, newAddr); err != nil {
+		t.Fatal("AddressAdd Returned Error: ", err)
+	}
+
+	// (2)	Wait for the Address
+	//			Expect: No error
+	// ------------------------------------------------------------------------
+
+	if err := splice.WaitAddressUsable(ctx, config.loopbackIntf, newAddr); err != nil {
+		t.Fatal("WaitAddressUsable Returned Error: ", err)
+	}
+}
+
+// Creates a veth pair with both ends brought up, so that IPv6 duplicate
+// address detection runs on either end.
+func upVethPair(t *testing.T) (*net.Interface, *net.Interface) {
+
+	nameB := RandomIntfName("vethb")
+
+	intfA, err := splice.LinkCreateVeth(RandomIntfName("vetha"), nameB, "")
+	if err != nil {
+		t.Fatal("LinkCreateVeth Returned Error: ", err)
+	}
+	intfB, err := net.InterfaceByName(nameB)
+	if err != nil {
+		t.Fatal("Failed to Get Veth Peer: ", err)
+	}
+
+	for _, intf := range []*net.Interface{intfA, intfB} {
+		if err := splice.LinkBringUp(intf); err != nil {
+			t.Fatal("LinkBringUp Returned Error: ", err)
+		}
+	}
+
+	return intfA, intfB
+}
+
+func TestWaitAddressUsable_IPv6(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	intf, _ := upVethPair(t)
+
+	// (1)	Add an IPv6 Address
+	//			Expect: The address is tentative while DAD runs
+	// ------------------------------------------------------------------------
+
+	newAddr := &net.IPNet{
+		IP:   net.ParseIP("2001:db8:5a1e::1"),
+		Mask: net.CIDRMask(64, 128),
+	}
+
+	if err := splice.AddressAdd(intf, newAddr); err != nil {
+		t.Fatal("AddressAdd Returned Error: ", err)
+	}
+
+	addrs, err := splice.AddressListDetailed(intf)
+	if err != nil {
+		t.Fatal("AddressListDetailed Returned Error: ", err)
+	}
+	for _, addr := range addrs {
+		if addr.IPNet.String() == newAddr.String() && addr.Flags&splice.AddressFlagTentative == 0 {
+			t.Fatal("Address Is Not Tentative: ", addr.Flags)
+		}
+	}
+
+	// (2)	Wait for the Address
+	//			Expect: No error once DAD completes
+	// ------------------------------------------------------------------------
+
+	if err := splice.WaitAddressUsable(ctx, intf, newAddr); err != nil {
+		t.Fatal("WaitAddressUsable Returned Error: ", err)
+	}
+}
+
+func TestWaitAddressUsable_DuplicateAddress(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	intfA, intfB := upVethPair(t)
+
+	dupAddr := &net.IPNet{
+		IP:   net.ParseIP("2001:db8:5a1e::1"),
+		Mask: net.CIDRMask(64, 128),
+	}
+
+	// (1)	Add an IPv6 Address to One End of the Pair
+	//			Expect: The address becomes usable
+	// ------------------------------------------------------------------------
+
+	if err := splice.AddressAdd(intfB, dupAddr); err != nil {
+		t.Fatal("AddressAdd Returned Error: ", err)
+	}
+	if err := splice.WaitAddressUsable(ctx, intfB, dupAddr); err != nil {
+		t.Fatal("WaitAddressUsable Returned Error: ", err)
+	}
+
+	// (2)	Add the Same Address to the Other End
+	//			Expect: ErrDuplicateAddress, DAD detects the peer's address
+	// ------------------------------------------------------------------------
+
+	if err := splice.AddressAdd(intfA, dupAddr); err != nil {
+		t.Fatal("AddressAdd Returned Error: ", err)
+	}
+	if err := splice.WaitAddressUsable(ctx, intfA, dupAddr); !errors.Is(err, splice.ErrDuplicateAddress) {
+		t.Fatal("WaitAddressUsable Did Not Return ErrDuplicateAddress: ", err)
+	}
+}
+
+func TestWaitAddressUsable_Deadline(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	// (1)	Wait for an Address Which is Never Added
+	//			Expect: The context deadline is exceeded
+	// ------------------------------------------------------------------------
+
+	if err := splice.WaitAddressUsable(ctx, config.loopbackIntf, RandomIPv4()); err != context.DeadlineExceeded {
+		t.Fatal("WaitAddressUsable Did Not Return a Deadline Error: ", err)
+	}
+}
+
+// ============================================================================
+//	WaitRoutePresent
+// ============================================================================
+
+func TestWaitRoutePresent(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// (1)	Add a Route Shortly After Waiting Begins
+	// ------------------------------------------------------------------------
+
+	routeNet := RandomIPv4()
+
+	GoInTest(t, func() {
+		time.Sleep(100 * time.Millisecond)
+		splice.RouteAddViaInterface(routeNet, config.loopbackIntf)
+	})
+
+	// (2)	Wait for the Route
+	//			Expect: No error, well before the polling interval elapses
+	// ------------------------------------------------------------------------
+
+	start := time.Now()
+
+	if err := splice.WaitRoutePresent(ctx, routeNet); err != nil {
+		t.Fatal("WaitRoutePresent Returned Error: ", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > 900*time.Millisecond {
+		t.Fatal("WaitRoutePresent Was Not Woken by the Route Event: ", elapsed)
+	}
+}
+
+func TestWaitRoutePresent_Deadline(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	// (1)	Wait for a Route Which is Never Added
+	//			Expect: The context deadline is exceeded
+	// ------------------------------------------------------------------------
+
+	if err := splice.WaitRoutePresent(ctx, RandomIPv4()); err != context.DeadlineExceeded {
+		t.Fatal("WaitRoutePresent Did Not Return a Deadline Error: ", err)
+	}
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2016 Arroyo Networks, LLC
+Copyright 2019 Project Contributors (See AUTHORS File).
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package splice
+
+import (
+	"encoding/base64"
+	"errors"
+	"net"
+	"time"
+)
+
+// A WireGuard public, private or preshared key.
+type WireGuardKey [32]byte
+
+// Parses a base64 encoded WireGuard key, as used by the 'wg' tool.
+func ParseWireGuardKey(s string) (WireGuardKey, error) {
+
+	var key WireGuardKey
+
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return key, err
+	}
+	if len(b) != len(key) {
+		return key, errors.New("WireGuard keys must be 32 bytes long")
+	}
+
+	copy(key[:], b)
+
+	return key, nil
+}
+
+// Returns the base64 encoding of the key.
+func (k WireGuardKey) String() string {
+	return base64.StdEncoding.EncodeToString(k[:])
+}
+
+// A peer of a WireGuard interface.
+type WireGuardPeer struct {
+	PublicKey           WireGuardKey
+	PresharedKey        *WireGuardKey // Optional symmetric key for post-quantum resistance.
+	Endpoint            *net.UDPAddr  // Initial address of the peer, updated by roaming.
+	AllowedIPs          []*net.IPNet  // Networks routed to, and accepted from, the peer.
+	PersistentKeepalive time.Duration // Interval of keepalive packets in whole seconds, 0 to disable.
+	Remove              bool          // Removes the peer instead of configuring it.
+}
+
+// The configuration applied to a WireGuard interface by WireGuardConfigure.
+// Fields left at their zero value are left unchanged.
+type WireGuardConfig struct {
+	PrivateKey   *WireGuardKey
+	ListenPort   int
+	FirewallMark int
+	ReplacePeers bool // Remove all peers which are not part of Peers.
+	Peers        []*WireGuardPeer
+
+	// If set, a route to each allowed IP of the configured peers is added via
+	// the interface using these options, and the routes to the allowed IPs
+	// listed on removed peers are deleted. The interface must be up. Routes
+	// are only changed for the allowed IPs given here: those of peers dropped
+	// by ReplacePeers, or no longer allowed for a peer, are left in place.
+	AllowedIPRoutes *RouteOptions
+}
@@ -0,0 +1,237 @@
+/*
+Copyright 2016 Arroyo Networks, LLC
+Copyright 2019 Project Contributors (See AUTHORS File).
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package splice
+
+import (
+	"encoding/binary"
+	"errors"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netlink/nl"
+	"golang.org/x/sys/unix"
+	"net"
+	"time"
+)
+
+// Provides WireGuard interface management for Linux using the kernel's
+// wireguard generic netlink family.
+
+// Commands and attributes of the wireguard generic netlink family.
+const (
+	wgCmdSetDevice = 1
+
+	wgDeviceAttrIfindex    = 1
+	wgDeviceAttrPrivateKey = 3
+	wgDeviceAttrFlags      = 5
+	wgDeviceAttrListenPort = 6
+	wgDeviceAttrFwmark     = 7
+	wgDeviceAttrPeers      = 8
+
+	wgDeviceFlagReplacePeers = 1 << 0
+
+	wgPeerAttrPublicKey                   = 1
+	wgPeerAttrPresharedKey                = 2
+	wgPeerAttrFlags                       = 3
+	wgPeerAttrEndpoint                    = 4
+	wgPeerAttrPersistentKeepaliveInterval = 5
+	wgPeerAttrAllowedIPs                  = 9
+
+	wgPeerFlagRemoveMe          = 1 << 0
+	wgPeerFlagReplaceAllowedIPs = 1 << 1
+
+	wgAllowedIPAttrFamily   = 1
+	wgAllowedIPAttrIPAddr   = 2
+	wgAllowedIPAttrCIDRMask = 3
+)
+
+// The longest persistent keepalive interval, which is sent in seconds as a
+// 16 bit value.
+const wgMaxKeepalive = 65535 * time.Second
+
+// Returns a nested netlink attribute.
+func wgNested(attrType int) *nl.RtAttr {
+	return nl.NewRtAttr(attrType|unix.NLA_F_NESTED, nil)
+}
+
+// Encodes a UDP address as a sockaddr_in or sockaddr_in6.
+func wgSockaddr(addr *net.UDPAddr) []byte {
+
+	native := nl.NativeEndian()
+
+	if ip := addr.IP.To4(); ip != nil {
+		b := make([]byte, unix.SizeofSockaddrInet4)
+		native.PutUint16(b[0:2], unix.AF_INET)
+		binary.BigEndian.PutUint16(b[2:4], uint16(addr.Port))
+		copy(b[4:8], ip)
+		return b
+	}
+
+	b := make([]byte, unix.SizeofSockaddrInet6)
+	native.PutUint16(b[0:2], unix.AF_INET6)
+	binary.BigEndian.PutUint16(b[2:4], uint16(addr.Port))
+	copy(b[8:24], addr.IP.To16())
+	if addr.Zone != "" {
+		if intf, err := net.InterfaceByName(addr.Zone); err == nil {
+			native.PutUint32(b[24:28], uint32(intf.Index))
+		}
+	}
+	return b
+}
+
+// Returns the netlink attribute describing a peer.
+func wgPeerAttr(index int, peer *WireGuardPeer) *nl.RtAttr {
+
+	attr := wgNested(index)
+	attr.AddRtAttr(wgPeerAttrPublicKey, peer.PublicKey[:])
+
+	if peer.Remove {
+		attr.AddRtAttr(wgPeerAttrFlags, nl.Uint32Attr(wgPeerFlagRemoveMe))
+		return attr
+	}
+
+	attr.AddRtAttr(wgPeerAttrFlags, nl.Uint32Attr(wgPeerFlagReplaceAllowedIPs))
+
+	if peer.PresharedKey != nil {
+		attr.AddRtAttr(wgPeerAttrPresharedKey, peer.PresharedKey[:])
+	}
+	if peer.Endpoint != nil {
+		attr.AddRtAttr(wgPeerAttrEndpoint, wgSockaddr(peer.Endpoint))
+	}
+
+	// The interval is always sent, so that a zero interval disables an
+	// existing keepalive.
+	seconds := uint16(peer.PersistentKeepalive / time.Second)
+	attr.AddRtAttr(wgPeerAttrPersistentKeepaliveInterval, nl.Uint16Attr(seconds))
+
+	if len(peer.AllowedIPs) == 0 {
+		return attr
+	}
+
+	allowedIPs := wgNested(wgPeerAttrAllowedIPs)
+	for i, ipNet := range peer.AllowedIPs {
+
+		family, ip := unix.AF_INET6, ipNet.IP.To16()
+		if ip4 := ipNet.IP.To4(); ip4 != nil {
+			family, ip = unix.AF_INET, ip4
+		}
+		ones, _ := ipNet.Mask.Size()
+
+		allowedIP := wgNested(i)
+		allowedIP.AddRtAttr(wgAllowedIPAttrFamily, nl.Uint16Attr(uint16(family)))
+		allowedIP.AddRtAttr(wgAllowedIPAttrIPAddr, ip)
+		allowedIP.AddRtAttr(wgAllowedIPAttrCIDRMask, nl.Uint8Attr(uint8(ones)))
+		allowedIPs.AddChild(allowedIP)
+	}
+	attr.AddChild(allowedIPs)
+
+	return attr
+}
+
+// Creates a WireGuard interface. The interface is created administratively
+// down and must be configured with WireGuardConfigure.
+// This is equivalent to 'ip link add <name> type wireguard'.
+func LinkCreateWireGuard(name string) (*net.Interface, error) {
+
+	attrs := netlink.NewLinkAttrs()
+	attrs.Name = name
+
+	return linkAdd(&netlink.Wireguard{LinkAttrs: attrs})
+}
+
+// Applies the given configuration to a WireGuard interface. The allowed IPs
+// of each configured peer replace any it had before.
+// This is equivalent to 'wg set <intf.Name> ...'.
+func WireGuardConfigure(intf *net.Interface, config *WireGuardConfig) error {
+
+	if config == nil {
+		return errors.New("No WireGuard configuration given")
+	}
+
+	for _, peer := range config.Peers {
+		keepalive := peer.PersistentKeepalive
+		if !peer.Remove && (keepalive < 0 || keepalive > wgMaxKeepalive || keepalive%time.Second != 0) {
+			return errors.New("Persistent keepalive must be a whole number of seconds up to 65535")
+		}
+	}
+
+	family, err := netlink.GenlFamilyGet("wireguard")
+	if err != nil {
+		return err
+	}
+
+	req := nl.NewNetlinkRequest(int(family.ID), unix.NLM_F_ACK)
+	req.AddData(&nl.Genlmsg{
+		Command: wgCmdSetDevice,
+		Version: uint8(family.Version),
+	})
+
+	req.AddData(nl.NewRtAttr(wgDeviceAttrIfindex, nl.Uint32Attr(uint32(intf.Index))))
+
+	if config.PrivateKey != nil {
+		req.AddData(nl.NewRtAttr(wgDeviceAttrPrivateKey, config.PrivateKey[:]))
+	}
+	if config.ListenPort != 0 {
+		req.AddData(nl.NewRtAttr(wgDeviceAttrListenPort, nl.Uint16Attr(uint16(config.ListenPort))))
+	}
+	if config.FirewallMark != 0 {
+		req.AddData(nl.NewRtAttr(wgDeviceAttrFwmark, nl.Uint32Attr(uint32(config.FirewallMark))))
+	}
+	if config.ReplacePeers {
+		req.AddData(nl.NewRtAttr(wgDeviceAttrFlags, nl.Uint32Attr(wgDeviceFlagReplacePeers)))
+	}
+
+	if len(config.Peers) != 0 {
+		peers := wgNested(wgDeviceAttrPeers)
+		for i, peer := range config.Peers {
+			peers.AddChild(wgPeerAttr(i, peer))
+		}
+		req.AddData(peers)
+	}
+
+	if _, err := req.Execute(unix.NETLINK_GENERIC, 0); err != nil {
+		return err
+	}
+
+	if config.AllowedIPRoutes == nil {
+		return nil
+	}
+
+	routeOpts := config.AllowedIPRoutes
+	deleteOpts := &RouteDeleteOptions{
+		Interface: intf,
+		Table:     routeOpts.Table,
+		VRF:       routeOpts.VRF,
+	}
+
+	for _, peer := range config.Peers {
+		for _, ipNet := range peer.AllowedIPs {
+			if peer.Remove {
+				err = RouteDelete(ipNet, deleteOpts)
+				if errors.Is(err, ErrNotFound) {
+					err = nil
+				}
+			} else {
+				err = RouteReplaceViaInterface(ipNet, intf, routeOpts)
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
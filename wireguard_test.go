@@ -0,0 +1,164 @@
+/*
+Copyright 2016 Arroyo Networks, LLC
+Copyright 2019 Project Contributors (See AUTHORS File).
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package splice_test
+
+import (
+	"github.com/arroyonetworks/splice"
+	"net"
+	"testing"
+	"time"
+)
+
+// ============================================================================
+//	ParseWireGuardKey
+// ============================================================================
+
+func TestParseWireGuardKey(t *testing.T) {
+
+	// (1)	Parse a Base64 Encoded Key
+	//			Expect: No error, and the key encodes back to the same string
+	// ------------------------------------------------------------------------
+
+	encoded := "YAnz7Tm2nq2U2KN0HA3xXZ5BpvPnYEA1p6B4u9qhV3o="
+
+	key, err := splice.ParseWireGuardKey(encoded)
+	if err != nil {
+		t.Fatal("ParseWireGuardKey Returned Error: ", err)
+	}
+
+	if key.String() != encoded {
+		t.Fatal("Parsed Key Has Unexpected Encoding: ", key.String())
+	}
+}
+
+func TestParseWireGuardKey_InvalidLength(t *testing.T) {
+
+	// (1)	Parse a Key Which is Too Short
+	//			Expect: Error
+	// ------------------------------------------------------------------------
+
+	if _, err := splice.ParseWireGuardKey("c2hvcnQ="); err == nil {
+		t.Fatal("ParseWireGuardKey Did Not Return an Error with a Short Key")
+	}
+}
+
+// ============================================================================
+//	WireGuardConfigure
+// ============================================================================
+
+func TestWireGuardConfigure(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	// (1)	Create and Bring Up a WireGuard Interface
+	// ------------------------------------------------------------------------
+
+	intf, err := splice.LinkCreateWireGuard(RandomIntfName("wg"))
+	SkipIfNotSupported(t, err)
+	if err != nil {
+		t.Fatal("LinkCreateWireGuard Returned Error: ", err)
+	}
+
+	if err := splice.LinkBringUp(intf); err != nil {
+		t.Fatal("LinkBringUp Returned Error: ", err)
+	}
+
+	// (2)	Configure the Interface with a Peer, Mirroring its Routes
+	//			Expect: No error
+	//
+	//			The peer's endpoint and keepalive are not read back from the
+	//			device, so only the netlink request being accepted is checked.
+	// ------------------------------------------------------------------------
+
+	privateKey, _ := splice.ParseWireGuardKey("YAnz7Tm2nq2U2KN0HA3xXZ5BpvPnYEA1p6B4u9qhV3o=")
+	publicKey, _ := splice.ParseWireGuardKey("xTIBA5rboUvnH4htodjb6e697QjLERt1NAB4mZqp8Dg=")
+
+	allowedIP := RandomIPv4()
+
+	wgConfig := &splice.WireGuardConfig{
+		PrivateKey: &privateKey,
+		ListenPort: 51820,
+		Peers: []*splice.WireGuardPeer{{
+			PublicKey:           publicKey,
+			Endpoint:            &net.UDPAddr{IP: net.ParseIP("192.0.2.1"), Port: 51820},
+			AllowedIPs:          []*net.IPNet{allowedIP},
+			PersistentKeepalive: 25 * time.Second,
+		}},
+		AllowedIPRoutes: &splice.RouteOptions{},
+	}
+
+	if err := splice.WireGuardConfigure(intf, wgConfig); err != nil {
+		t.Fatal("WireGuardConfigure Returned Error: ", err)
+	}
+
+	// (3)	Expect: A route to the allowed IP exists via the interface
+	// ------------------------------------------------------------------------
+
+	routes, err := splice.RouteList(&splice.RouteFilter{Destination: allowedIP})
+	if err != nil {
+		t.Fatal("RouteList Returned Error: ", err)
+	}
+	if len(routes) != 1 || routes[0].Interface == nil || routes[0].Interface.Index != intf.Index {
+		t.Fatal("Allowed IP Was Not Routed via the WireGuard Interface: ", routes)
+	}
+
+	// (4)	Remove the Peer
+	//			Expect: No error
+	// ------------------------------------------------------------------------
+
+	wgConfig.Peers[0].Remove = true
+
+	if err := splice.WireGuardConfigure(intf, wgConfig); err != nil {
+		t.Fatal("WireGuardConfigure Returned Error: ", err)
+	}
+
+	// (5)	Expect: The route to the allowed IP was deleted
+	// ------------------------------------------------------------------------
+
+	routes, err = splice.RouteList(&splice.RouteFilter{Destination: allowedIP})
+	if err != nil {
+		t.Fatal("RouteList Returned Error: ", err)
+	}
+	if len(routes) != 0 {
+		t.Fatal("Route to the Allowed IP of a Removed Peer Was Not Deleted: ", routes)
+	}
+}
+
+func TestWireGuardConfigure_InvalidKeepalive(t *testing.T) {
+
+	config := SetUpTest(t)
+	defer config.tearDownTest()
+
+	publicKey, _ := splice.ParseWireGuardKey("xTIBA5rboUvnH4htodjb6e697QjLERt1NAB4mZqp8Dg=")
+
+	// (1)	Configure a Peer with a Sub-Second Keepalive
+	//			Expect: Error
+	// ------------------------------------------------------------------------
+
+	wgConfig := &splice.WireGuardConfig{
+		Peers: []*splice.WireGuardPeer{{
+			PublicKey:           publicKey,
+			PersistentKeepalive: 1500 * time.Millisecond,
+		}},
+	}
+
+	if err := splice.WireGuardConfigure(config.loopbackIntf, wgConfig); err == nil {
+		t.Fatal("WireGuardConfigure Did Not Return an Error with a Sub-Second Keepalive")
+	}
+}